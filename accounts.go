@@ -0,0 +1,122 @@
+/*
+Builds a per-account view of windows authentication activity.  Accounts are pulled
+from the kerberos client principal and the ntlm username and joined by uid to the smb
+shares and files touched on the same connection.
+*/
+
+package zeekparse
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AccountActivity is everything seen for a single account across the kerberos, ntlm and smb logs.
+type AccountActivity struct {
+	Account   string    // lowercased user name without realm or domain
+	Domains   []string  // kerberos realms and ntlm domains the account was seen with
+	Hosts     []string  // hosts the account authenticated from
+	Servers   []string  // hosts the account authenticated to
+	Shares    []string  // smb tree paths mapped on connections the account authenticated on
+	Files     []string  // smb file paths touched on connections the account authenticated on
+	AuthCount int       // number of kerberos and ntlm authentications seen
+	Failures  int       // number of failed kerberos and ntlm authentications seen
+	FirstSeen time.Time // earliest authentication seen
+	LastSeen  time.Time // latest authentication seen
+}
+
+func (a *AccountActivity) Print() {
+	fmt.Printf("%s (%s--%s) auths:%d failures:%d\n", a.Account,
+		a.FirstSeen.Format("01/02/06"), a.LastSeen.Format("01/02/06"), a.AuthCount, a.Failures)
+	fmt.Printf("\tfrom:%s to:%s shares:%s\n", a.Hosts, a.Servers, a.Shares)
+}
+
+// record a single authentication against the account.
+func (a *AccountActivity) addAuth(givenEntryTS time.Time, givenDomain, givenOrig, givenResp string, givenSuccess bool) {
+	a.Domains = appendUnique(a.Domains, givenDomain)
+	a.Hosts = appendUnique(a.Hosts, givenOrig)
+	a.Servers = appendUnique(a.Servers, givenResp)
+	a.AuthCount++
+	if !givenSuccess {
+		a.Failures++
+	}
+	if a.FirstSeen.IsZero() || givenEntryTS.Before(a.FirstSeen) {
+		a.FirstSeen = givenEntryTS
+	}
+	if givenEntryTS.After(a.LastSeen) {
+		a.LastSeen = givenEntryTS
+	}
+}
+
+// BuildAccountActivity joins kerberos and ntlm authentications to the smb mappings and file
+// accesses that share their uid.  The result is keyed by the lowercased account name.
+func BuildAccountActivity(givenKerberos []KerberosEntry, givenNtlm []NtlmEntry,
+	givenMappings []SmbMappingEntry, givenFiles []SmbFilesEntry) map[string]*AccountActivity {
+
+	accounts := make(map[string]*AccountActivity)
+	accountsByUid := make(map[string][]*AccountActivity)
+
+	getAccount := func(givenName, givenUid string) *AccountActivity {
+		name := strings.ToLower(givenName)
+		a, ok := accounts[name]
+		if !ok {
+			a = &AccountActivity{Account: name}
+			accounts[name] = a
+		}
+		for _, thisAccount := range accountsByUid[givenUid] {
+			if thisAccount == a {
+				return a
+			}
+		}
+		accountsByUid[givenUid] = append(accountsByUid[givenUid], a)
+		return a
+	}
+
+	for _, thisKrb := range givenKerberos {
+		user := thisKrb.ClientUser()
+		if len(user) == 0 {
+			continue
+		}
+		var realm string
+		if idx := strings.Index(thisKrb.Client, "/"); idx >= 0 {
+			realm = thisKrb.Client[idx+1:]
+		}
+		getAccount(user, thisKrb.Uid).addAuth(thisKrb.TS, realm, thisKrb.IdOrigH, thisKrb.IdRespH, thisKrb.Success)
+	}
+
+	for _, thisNtlm := range givenNtlm {
+		if len(thisNtlm.Username) == 0 {
+			continue
+		}
+		getAccount(thisNtlm.Username, thisNtlm.Uid).addAuth(thisNtlm.TS, thisNtlm.DomainName,
+			thisNtlm.IdOrigH, thisNtlm.IdRespH, thisNtlm.Success)
+	}
+
+	for _, thisMapping := range givenMappings {
+		for _, thisAccount := range accountsByUid[thisMapping.Uid] {
+			thisAccount.Shares = appendUnique(thisAccount.Shares, thisMapping.Path)
+		}
+	}
+
+	for _, thisFile := range givenFiles {
+		fullPath := thisFile.Name
+		if len(thisFile.Path) > 0 {
+			fullPath = thisFile.Path + "\\" + thisFile.Name
+		}
+		for _, thisAccount := range accountsByUid[thisFile.Uid] {
+			thisAccount.Files = appendUnique(thisAccount.Files, fullPath)
+		}
+	}
+
+	for _, thisAccount := range accounts {
+		sort.Strings(thisAccount.Domains)
+		sort.Strings(thisAccount.Hosts)
+		sort.Strings(thisAccount.Servers)
+		sort.Strings(thisAccount.Shares)
+		sort.Strings(thisAccount.Files)
+	}
+
+	return accounts
+}
@@ -0,0 +1,31 @@
+package zeekparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBuildAccountActivity(t *testing.T) {
+	allKrb, err := ParseKerberosLog("test_input/simple_kerberos.log")
+	assert.NoError(t, err)
+	allNtlm, err := ParseNtlmLog("test_input/simple_ntlm.log")
+	assert.NoError(t, err)
+	allMappings, err := ParseSmbMappingLog("test_input/simple_smb_mapping.log")
+	assert.NoError(t, err)
+	allFiles, err := ParseSmbFilesLog("test_input/simple_smb_files.log")
+	assert.NoError(t, err)
+
+	accounts := BuildAccountActivity(allKrb, allNtlm, allMappings, allFiles)
+	assert.Equal(t, 3, len(accounts))
+
+	// jdoe shows up in both kerberos and ntlm
+	jdoe := accounts["jdoe"]
+	assert.Equal(t, 3, jdoe.AuthCount)
+	assert.Equal(t, []string{"CORP", "CORP.LOCAL"}, jdoe.Domains)
+	assert.Equal(t, []string{"10.0.0.21", "10.0.0.40"}, jdoe.Hosts)
+	assert.Equal(t, 3, len(jdoe.Shares))
+	assert.Equal(t, 2, len(jdoe.Files))
+
+	assert.Equal(t, 1, accounts["svc_backup"].Failures)
+	assert.Equal(t, 0, len(accounts["administrator"].Shares))
+}
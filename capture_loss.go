@@ -0,0 +1,125 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+// capture_loss log format described in https://docs.zeek.org/en/master/scripts/policy/misc/capture-loss.zeek.html#type-CaptureLoss::Info
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// CaptureLossEntry is a fully parsed capture_loss.log line.
+type CaptureLossEntry struct {
+	TS time.Time // TS:time - timestamp
+	// ---------
+	TSDelta     Opt[float64] // ts_delta:interval - time difference from the previous measurement
+	Peer        string       // peer:string - name of the zeek instance reporting loss
+	Gaps        Opt[int]     // gaps:count - number of missed ACKs from the previous measurement interval
+	Acks        Opt[int]     // acks:count - total number of ACKs seen in the previous measurement interval
+	PercentLost Opt[float64] // percent_lost:double - percentage of ACKs seen where the data being ACKed wasn't seen
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (c *CaptureLossEntry) Print() {
+	fmt.Printf("(%s) %s lost %s%% (%s gaps / %s acks) over %ss\n", c.TS.String(), c.Peer, c.PercentLost, c.Gaps, c.Acks, c.TSDelta)
+}
+
+func (c *CaptureLossEntry) ShortPrint() {
+	fmt.Printf("[%s] %s %s%%\n", c.TS, c.Peer, c.PercentLost)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToCaptureLossStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (captureLossEntry CaptureLossEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			captureLossEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "ts_delta":
+			captureLossEntry.TSDelta, err = optFloat(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "peer":
+			captureLossEntry.Peer = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "gaps":
+			captureLossEntry.Gaps, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "acks":
+			captureLossEntry.Acks, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "percent_lost":
+			captureLossEntry.PercentLost, err = optFloat(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseCaptureLossLog will parse through the given single capture_loss log (passed as a filename string)
+func ParseCaptureLossLog(givenFilename string) (parsedResults []CaptureLossEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes CaptureLossEntry
+		thisRes, err = thisLogEntryToCaptureLossStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseCaptureLossRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseCaptureLossRecurse(givenDirectory string) (allResults []CaptureLossEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "capture_loss") {
+		thisResult, parseErr := ParseCaptureLossLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllCaptureLossForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed CaptureLossEntry objects
+func GetAllCaptureLossForDay(givenDay string, givenZeekDir ...string) (allRes []CaptureLossEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseCaptureLossRecurse(zeekDir + givenDay + "/")
+	return
+}
@@ -0,0 +1,20 @@
+package zeekparse
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThisLogEntryToCaptureLossStruct(t *testing.T) {
+	log.SetFormatter(&log.TextFormatter{ForceColors: true})
+	log.SetLevel(log.InfoLevel)
+
+	// compressed case
+	compressedResults, header, compErr := parseZeekLog("test_input/simple_capture_loss.log.gz")
+	for _, thisResult := range compressedResults {
+		_, parseErr := thisLogEntryToCaptureLossStruct(thisResult, header)
+		assert.NoError(t, parseErr)
+	}
+	assert.NoError(t, compErr)
+}
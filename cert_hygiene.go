@@ -0,0 +1,257 @@
+/*
+Certificate hygiene checks on x509.log entries.  Each certificate is checked as it was when
+zeek saw it (the entry TS) so old logs report what was wrong at the time.
+*/
+
+package zeekparse
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ------------------------------
+// ----- Helper Structures ------
+// ------------------------------
+
+// FindingSeverity is how serious a finding is, ordered from least to most serious.
+type FindingSeverity int
+
+const (
+	SeverityInfo FindingSeverity = iota
+	SeverityLow
+	SeverityMedium
+	SeverityHigh
+)
+
+func (f FindingSeverity) String() string {
+	switch f {
+	case SeverityInfo:
+		return "info"
+	case SeverityLow:
+		return "low"
+	case SeverityMedium:
+		return "medium"
+	case SeverityHigh:
+		return "high"
+	}
+	return fmt.Sprintf("severity-%d", int(f))
+}
+
+// CertCheck names a single certificate check.
+type CertCheck string
+
+const (
+	CertExpired       CertCheck = "expired"        // seen after its not valid after time
+	CertNotYetValid   CertCheck = "not-yet-valid"  // seen before its not valid before time
+	CertLongValidity  CertCheck = "long-validity"  // leaf valid for longer than the CA/B forum allows
+	CertWeakKey       CertCheck = "weak-key"       // RSA or DSA under 2048 bits, EC under 256 bits
+	CertSmallExponent CertCheck = "small-exponent" // RSA public exponent under 65537
+	CertWeakSignature CertCheck = "weak-signature" // signed with MD2, MD5 or SHA-1
+	CertSelfSigned    CertCheck = "self-signed"    // subject and issuer are the same
+	CertWildcard      CertCheck = "wildcard"       // CN or a SAN dns name is a wildcard
+)
+
+// CertFinding is a single problem found with a certificate.
+type CertFinding struct {
+	Check    CertCheck
+	Severity FindingSeverity
+	Detail   string
+}
+
+func (f CertFinding) String() string {
+	return fmt.Sprintf("[%s] %s: %s", f.Severity, f.Check, f.Detail)
+}
+
+// maxLeafValidity is the longest a leaf certificate may be valid for, by the date it was issued.
+// From the CA/B forum baseline requirements (section 6.3.2) including the ballot SC-081 phase down.
+var maxLeafValidity = []struct {
+	issuedFrom time.Time
+	maxDays    int
+}{
+	{time.Date(2029, 3, 15, 0, 0, 0, 0, time.UTC), 47},
+	{time.Date(2027, 3, 15, 0, 0, 0, 0, time.UTC), 100},
+	{time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), 200},
+	{time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC), 398},
+	{time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC), 825},
+	{time.Date(2015, 4, 1, 0, 0, 0, 0, time.UTC), 1185},
+}
+
+// maxValidityDays returns the CA/B limit for a leaf issued at the given time, 0 if there was none.
+func maxValidityDays(givenIssued time.Time) int {
+	for _, thisLimit := range maxLeafValidity {
+		if !givenIssued.Before(thisLimit.issuedFrom) {
+			return thisLimit.maxDays
+		}
+	}
+	return 0
+}
+
+// formatValidity returns the given validity period as whole days followed by any remainder
+// (ie: 398 days 23h0m0s).
+func formatValidity(givenValidity time.Duration) string {
+	days := givenValidity / (24 * time.Hour)
+	formatted := fmt.Sprintf("%d days", days)
+	if remainder := givenValidity - days*24*time.Hour; remainder > 0 {
+		formatted += " " + remainder.String()
+	}
+	return formatted
+}
+
+// ------------------------------
+// ----    Entry Helpers   ------
+// ------------------------------
+
+// IsCA tells if the certificate has the basic constraints CA flag set.
+func (s *X509Entry) IsCA() bool {
+	return s.BasicConstraintsCA.Value()
+}
+
+// IsWildcard tells if the subject CN or any SAN dns name is a wildcard.
+func (s *X509Entry) IsWildcard() bool {
+	if strings.HasPrefix(s.CommonName(), "*.") {
+		return true
+	}
+	for _, thisName := range s.SanDns {
+		if strings.HasPrefix(thisName, "*.") {
+			return true
+		}
+	}
+	return false
+}
+
+// Findings runs every certificate check against the entry and returns what was found, most
+// serious first.  Checks that need a field zeek did not log are skipped.
+func (s *X509Entry) Findings() (findings []CertFinding) {
+	add := func(givenCheck CertCheck, givenSeverity FindingSeverity, givenFormat string, givenArgs ...interface{}) {
+		findings = append(findings, CertFinding{Check: givenCheck, Severity: givenSeverity, Detail: fmt.Sprintf(givenFormat, givenArgs...)})
+	}
+	isCA := s.IsCA()
+	selfSigned := s.IsSelfSigned()
+
+	// validity relative to when the certificate was seen
+	if !s.CertNotValidAfter.IsZero() && s.TS.After(s.CertNotValidAfter) {
+		add(CertExpired, SeverityHigh, "expired %s, seen %s", s.CertNotValidAfter.UTC().Format(time.RFC3339), s.TS.UTC().Format(time.RFC3339))
+	}
+	if !s.CertNotValidBefore.IsZero() && s.TS.Before(s.CertNotValidBefore) {
+		add(CertNotYetValid, SeverityMedium, "not valid until %s, seen %s", s.CertNotValidBefore.UTC().Format(time.RFC3339), s.TS.UTC().Format(time.RFC3339))
+	}
+	if !isCA && !s.CertNotValidBefore.IsZero() && !s.CertNotValidAfter.IsZero() {
+		// the CA/B validity period is inclusive of both ends so includes the not after second
+		validity := s.CertNotValidAfter.Sub(s.CertNotValidBefore) + time.Second
+		if maxDays := maxValidityDays(s.CertNotValidBefore); maxDays > 0 && validity > time.Duration(maxDays)*24*time.Hour {
+			add(CertLongValidity, SeverityMedium, "valid for %s, limit is %d days", formatValidity(validity), maxDays)
+		}
+	}
+
+	// key strength
+	keyType := strings.ToLower(s.CertKeyType)
+	if keyLength, ok := s.CertKeyLength.Get(); ok {
+		switch {
+		case (keyType == "rsa" || keyType == "dsa") && keyLength < 2048:
+			add(CertWeakKey, SeverityHigh, "%d bit %s key", keyLength, keyType)
+		case keyType == "ecdsa" && keyLength < 256:
+			add(CertWeakKey, SeverityHigh, "%d bit %s key", keyLength, keyType)
+		}
+	}
+	if keyType == "rsa" && len(s.CertExponent) > 0 {
+		if exponent, err := strconv.ParseUint(s.CertExponent, 10, 64); err == nil && exponent < 65537 {
+			add(CertSmallExponent, SeverityMedium, "public exponent %d", exponent)
+		}
+	}
+
+	// signature, a weak hash on a self-signed root doesn't matter as the signature is never checked
+	sigAlg := strings.ToLower(s.CertSigAlg)
+	var weakHash string
+	switch {
+	case strings.Contains(sigAlg, "md2"):
+		weakHash = "MD2"
+	case strings.Contains(sigAlg, "md5"):
+		weakHash = "MD5"
+	case strings.Contains(sigAlg, "sha1"):
+		weakHash = "SHA-1"
+	}
+	if len(weakHash) > 0 {
+		switch {
+		case isCA && selfSigned:
+			add(CertWeakSignature, SeverityInfo, "%s signature on a self-signed root", weakHash)
+		case weakHash == "SHA-1":
+			add(CertWeakSignature, SeverityMedium, "%s signature (%s)", weakHash, s.CertSigAlg)
+		default:
+			add(CertWeakSignature, SeverityHigh, "%s signature (%s)", weakHash, s.CertSigAlg)
+		}
+	}
+
+	if selfSigned {
+		if isCA {
+			add(CertSelfSigned, SeverityInfo, "self-signed CA %s", s.CertSubject)
+		} else {
+			add(CertSelfSigned, SeverityMedium, "self-signed leaf %s", s.CertSubject)
+		}
+	}
+	if s.IsWildcard() {
+		add(CertWildcard, SeverityLow, "wildcard certificate for %s", s.CommonName())
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Severity > findings[j].Severity
+	})
+	return
+}
+
+// CertHygiene is a certificate along with what was found wrong with it.
+type CertHygiene struct {
+	Cert     X509Entry
+	Findings []CertFinding
+}
+
+// WorstSeverity returns the severity of the most serious finding, ok is false if there were none.
+func (c *CertHygiene) WorstSeverity() (severity FindingSeverity, ok bool) {
+	if len(c.Findings) == 0 {
+		return
+	}
+	return c.Findings[0].Severity, true
+}
+
+func (c *CertHygiene) Print() {
+	fmt.Printf("%s (%s)\n", c.Cert.CertSubject, c.Cert.CertId())
+	for _, thisFinding := range c.Findings {
+		fmt.Printf("\t%s\n", thisFinding.String())
+	}
+}
+
+// CheckCerts runs the certificate checks on each unique certificate (by CertId) in the given
+// entries, returning only those with a finding at or above the given severity.  Results are
+// ordered most serious first and then by subject.
+func CheckCerts(givenCerts []X509Entry, givenMinSeverity FindingSeverity) (results []CertHygiene) {
+	seen := make(map[string]bool)
+	for _, thisCert := range givenCerts {
+		if certId := thisCert.CertId(); len(certId) > 0 {
+			if seen[certId] {
+				continue
+			}
+			seen[certId] = true
+		}
+		var kept []CertFinding
+		for _, thisFinding := range thisCert.Findings() {
+			if thisFinding.Severity >= givenMinSeverity {
+				kept = append(kept, thisFinding)
+			}
+		}
+		if len(kept) > 0 {
+			results = append(results, CertHygiene{Cert: thisCert, Findings: kept})
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		worstI, _ := results[i].WorstSeverity()
+		worstJ, _ := results[j].WorstSeverity()
+		if worstI != worstJ {
+			return worstI > worstJ
+		}
+		return results[i].Cert.CertSubject < results[j].Cert.CertSubject
+	})
+	return
+}
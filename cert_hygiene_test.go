@@ -0,0 +1,94 @@
+package zeekparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// findingChecks returns the checks of the given findings in order.
+func findingChecks(givenFindings []CertFinding) (checks []CertCheck) {
+	for _, thisFinding := range givenFindings {
+		checks = append(checks, thisFinding.Check)
+	}
+	return
+}
+
+func TestCertFindings(t *testing.T) {
+	allX509, err := ParseX509Log("test_input/simple_x509_hygiene.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, len(allX509))
+
+	printer := allX509[0].Findings()
+	assert.Equal(t, []CertCheck{CertWeakKey, CertLongValidity, CertSmallExponent, CertWeakSignature, CertSelfSigned, CertWildcard},
+		findingChecks(printer))
+	assert.Equal(t, SeverityHigh, printer[0].Severity)
+	assert.Equal(t, "[high] weak-key: 1024 bit rsa key", printer[0].String())
+	assert.Equal(t, "valid for 4018 days 1s, limit is 825 days", printer[1].Detail)
+
+	expired := allX509[1].Findings()
+	assert.Equal(t, []CertCheck{CertExpired, CertWeakSignature}, findingChecks(expired))
+	assert.Equal(t, SeverityHigh, expired[1].Severity)
+
+	// a self-signed root with a SHA-1 signature is normal
+	root := allX509[2].Findings()
+	assert.Equal(t, []CertCheck{CertWeakSignature, CertSelfSigned}, findingChecks(root))
+	assert.Equal(t, SeverityInfo, root[0].Severity)
+	assert.Equal(t, SeverityInfo, root[1].Severity)
+
+	assert.Equal(t, []CertCheck{CertNotYetValid}, findingChecks(allX509[3].Findings()))
+
+	// well configured certificates have no findings
+	allGood, err := ParseX509Log("test_input/simple_x509_fingerprint.log")
+	assert.NoError(t, err)
+	for _, thisX509 := range allGood {
+		assert.Empty(t, thisX509.Findings(), thisX509.CertSubject)
+	}
+}
+
+func TestCertLongValidity(t *testing.T) {
+	issued := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	leaf := X509Entry{TS: issued, CertNotValidBefore: issued}
+
+	// the longest a leaf issued in 2021 may be valid for, the not after second is included
+	leaf.CertNotValidAfter = issued.Add(398*24*time.Hour - time.Second)
+	assert.Empty(t, leaf.Findings())
+
+	// part of a day over the limit still counts
+	leaf.CertNotValidAfter = issued.Add(398*24*time.Hour + 23*time.Hour)
+	findings := leaf.Findings()
+	assert.Equal(t, []CertCheck{CertLongValidity}, findingChecks(findings))
+	assert.Equal(t, "valid for 398 days 23h0m1s, limit is 398 days", findings[0].Detail)
+
+	leaf.CertNotValidAfter = issued.Add(398 * 24 * time.Hour)
+	assert.Equal(t, []CertCheck{CertLongValidity}, findingChecks(leaf.Findings()))
+}
+
+func TestMaxValidityDays(t *testing.T) {
+	assert.Equal(t, 0, maxValidityDays(time.Unix(1400000000, 0)))
+	assert.Equal(t, 1185, maxValidityDays(time.Unix(1500000000, 0)))
+	assert.Equal(t, 398, maxValidityDays(time.Unix(1700000000, 0)))
+	assert.Equal(t, 200, maxValidityDays(time.Unix(1780000000, 0)))
+}
+
+func TestCheckCerts(t *testing.T) {
+	allX509, err := ParseX509Log("test_input/simple_x509_hygiene.log")
+	assert.NoError(t, err)
+
+	// the printer cert is logged twice but reported once
+	results := CheckCerts(allX509, SeverityInfo)
+	assert.Equal(t, 4, len(results))
+	assert.Equal(t, "CN=*.printer.lan", results[0].Cert.CertSubject)
+	assert.Equal(t, "CN=old.example.com,O=Old Example", results[1].Cert.CertSubject)
+	worst, ok := results[3].WorstSeverity()
+	assert.True(t, ok)
+	assert.Equal(t, SeverityInfo, worst)
+
+	results = CheckCerts(allX509, SeverityMedium)
+	assert.Equal(t, 3, len(results))
+	for _, thisResult := range results {
+		for _, thisFinding := range thisResult.Findings {
+			assert.GreaterOrEqual(t, int(thisFinding.Severity), int(SeverityMedium))
+		}
+	}
+}
@@ -10,14 +10,29 @@ import (
 	"time"
 )
 
-// Proto is an enum of tcp protocol, either TCP or UDP
+// Proto is an enum of transport protocol, either TCP, UDP or ICMP
 type Proto string
 const (
 	TCP  Proto = "TCP"
 	UDP  Proto = "UDP"
+	ICMP Proto = "ICMP"
 	NONE Proto = "None"
 )
 
+// ParseProto converts a zeek transport_proto value (tcp, udp, icmp) to a Proto.  Anything
+// else, including unknown_transport, becomes NONE.
+func ParseProto(givenValue string) Proto {
+	switch givenValue {
+	case "tcp":
+		return TCP
+	case "udp":
+		return UDP
+	case "icmp":
+		return ICMP
+	}
+	return NONE
+}
+
 // UnixStrToTime will convert timestamps from unix format to a time.time
 func UnixStrToTime(givenUnixStr string) (resultTime time.Time, err error) {
 	var splitUnixTime []string
@@ -117,6 +132,23 @@ func GetZeekDir(givenZeekDir []string) string{
 // PathRecurse is used for day recursion type functions.  Pass in a directory and a fragment
 // of the filename and it will return a channel of strings that have filenames.
 func PathRecurse(givenDirectory string, givenFilenameFragment string) <-chan string {
+	return pathRecurseMatching(givenDirectory, func(path string) bool {
+		return strings.Contains(path, givenFilenameFragment+".")
+	})
+}
+
+// PathRecursePrefix is like PathRecurse but only returns files whose name (without the
+// directory) starts with the given prefix.  Use it for logs whose name is also the end of
+// another log's name (ie: "weird." so net_weird and flow_weird logs are skipped).
+func PathRecursePrefix(givenDirectory string, givenFilenamePrefix string) <-chan string {
+	return pathRecurseMatching(givenDirectory, func(path string) bool {
+		return strings.HasPrefix(filepath.Base(path), givenFilenamePrefix)
+	})
+}
+
+// pathRecurseMatching walks the given directory and returns a channel of the filenames the
+// given match function accepts.
+func pathRecurseMatching(givenDirectory string, givenMatch func(path string) bool) <-chan string {
 	var filenames []string
 
 	_ = filepath.Walk(givenDirectory,
@@ -124,7 +156,7 @@ func PathRecurse(givenDirectory string, givenFilenameFragment string) <-chan str
 			if err != nil {
 				return err
 			}
-			if strings.Contains(path, givenFilenameFragment+".") {
+			if givenMatch(path) {
 				filenames = append(filenames, path)
 			}
 			return err
@@ -139,4 +171,26 @@ func PathRecurse(givenDirectory string, givenFilenameFragment string) <-chan str
 	}()
 
 	return thisChan
-}
\ No newline at end of file
+}
+
+// strSliceNilIfUnset is a convenience function for parsers that will split a zeek
+// set or vector into a slice, returning nil if the value is unset or empty.
+func strSliceNilIfUnset(givenValue string, givenLogOpts *LogFileOpts) []string {
+	if givenValue == givenLogOpts.unsetField || givenValue == givenLogOpts.emptyField {
+		return nil
+	}
+	return strings.Split(givenValue, givenLogOpts.setSeparator)
+}
+
+// appendUnique appends the given value to the slice if it isn't blank and not already present.
+func appendUnique(givenSlice []string, givenValue string) []string {
+	if len(givenValue) == 0 {
+		return givenSlice
+	}
+	for _, thisValue := range givenSlice {
+		if thisValue == givenValue {
+			return givenSlice
+		}
+	}
+	return append(givenSlice, givenValue)
+}
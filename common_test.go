@@ -27,3 +27,26 @@ func TestUnixStrToTime(t *testing.T) {
 	result, err = UnixStrToTime(failTimeStr)
 	assert.Error(t, err)
 }
+
+func TestPathRecursePrefix(t *testing.T) {
+	var found []string
+	for thisFile := range PathRecursePrefix("test_input", "simple_weird.") {
+		found = append(found, thisFile)
+	}
+	assert.ElementsMatch(t, []string{"test_input/simple_weird.log", "test_input/simple_weird.log.gz"}, found)
+
+	// a prefix only matches the start of the filename, not the directory or the middle
+	found = nil
+	for thisFile := range PathRecursePrefix("test_input", "weird.") {
+		found = append(found, thisFile)
+	}
+	assert.Empty(t, found)
+}
+
+func TestParseProto(t *testing.T) {
+	assert.Equal(t, TCP, ParseProto("tcp"))
+	assert.Equal(t, UDP, ParseProto("udp"))
+	assert.Equal(t, ICMP, ParseProto("icmp"))
+	assert.Equal(t, NONE, ParseProto("unknown_transport"))
+	assert.Equal(t, NONE, ParseProto("-"))
+}
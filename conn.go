@@ -76,20 +76,29 @@ type ConnEntry struct {
 	IdRespP int       // id_resp_p:port - responders port
 	Proto   Proto     // Proto:enum - protocol
 	// ---------------
-	Service     string       // service:str An identification of an application protocol being sent over the connection.
-	Duration    float64      // duration:float64 How long the connection lasted. For 3-way or 4-way connection tear-downs, this will not include the final ACK.
-	OrigBytes   int          // orig_bytes:int he number of payload bytes the originator sent. For TCP this is taken from sequence numbers and might be inaccurate (e.g., due to large connections).
-	RespBytes   int          // resp_bytes:int The number of payload bytes the responder sent. See orig_bytes.
-	ConnState   ConnStateObj // conn_state:ConnState
-	LocalOrig   bool         // local_orig:bool If the connection is originated locally, this value will be T. If it was originated remotely it will be F. In the case that the Site::local_nets variable is undefined, this field will be left empty at all times.
-	LocalResp   bool         // local_resp:bool If the connection is responded to locally, this value will be T. If it was responded to remotely it will be F. In the case that the Site::local_nets variable is undefined, this field will be left empty at all times.
-	MissedBytes int          // missed_bytes:int If the connection is responded to locally, this value will be T. If it was responded to remotely it will be F. In the case that the Site::local_nets variable is undefined, this field will be left empty at all times.
-	History     string       // history:str state history as string
-	OrigPkts    int          // orig_pkts:int Number of packets that the originator sent. Only set if use_conn_size_analyzer = T.
-	OrigIpBytes int          // orig_ip_bytes:int Number of IP level bytes that the originator sent (as seen on the wire, taken from the IP total_length header field). Only set if use_conn_size_analyzer = T.
-	RespPkts    int          // resp_pkts:int Number of packets that the responder sent. Only set if use_conn_size_analyzer = T.
-	RespIpBytes int          // resp_ip_bytes:int Number of IP level bytes that the responder sent (as seen on the wire, taken from the IP total_length header field). Only set if use_conn_size_analyzer = T.
-	// tunnel_parents: TODO: unimplemented: If this connection was over a tunnel, indicate the uid values for any encapsulating parent connections used over the lifetime of this inner connection.
+	Service            string       // service:str An identification of an application protocol being sent over the connection.
+	Duration           Opt[float64] // duration:float64 How long the connection lasted. For 3-way or 4-way connection tear-downs, this will not include the final ACK.
+	OrigBytes          Opt[int]     // orig_bytes:int he number of payload bytes the originator sent. For TCP this is taken from sequence numbers and might be inaccurate (e.g., due to large connections).
+	RespBytes          Opt[int]     // resp_bytes:int The number of payload bytes the responder sent. See orig_bytes.
+	ConnState          ConnStateObj // conn_state:ConnState
+	LocalOrig          Opt[bool]    // local_orig:bool If the connection is originated locally, this value will be T. If it was originated remotely it will be F. In the case that the Site::local_nets variable is undefined, this field will be left empty at all times.
+	LocalResp          Opt[bool]    // local_resp:bool If the connection is responded to locally, this value will be T. If it was responded to remotely it will be F. In the case that the Site::local_nets variable is undefined, this field will be left empty at all times.
+	MissedBytes        Opt[int]     // missed_bytes:int If the connection is responded to locally, this value will be T. If it was responded to remotely it will be F. In the case that the Site::local_nets variable is undefined, this field will be left empty at all times.
+	History            string       // history:str state history as string
+	OrigPkts           Opt[int]     // orig_pkts:int Number of packets that the originator sent. Only set if use_conn_size_analyzer = T.
+	OrigIpBytes        Opt[int]     // orig_ip_bytes:int Number of IP level bytes that the originator sent (as seen on the wire, taken from the IP total_length header field). Only set if use_conn_size_analyzer = T.
+	RespPkts           Opt[int]     // resp_pkts:int Number of packets that the responder sent. Only set if use_conn_size_analyzer = T.
+	RespIpBytes        Opt[int]     // resp_ip_bytes:int Number of IP level bytes that the responder sent (as seen on the wire, taken from the IP total_length header field). Only set if use_conn_size_analyzer = T.
+	TunnelParents      []string     // tunnel_parents:set[string] If this connection was over a tunnel, indicate the uid values for any encapsulating parent connections used over the lifetime of this inner connection.
+	Vlan               Opt[int]     // vlan:int The outer VLAN for this connection, if applicable.
+	InnerVlan          Opt[int]     // inner_vlan:int The inner VLAN for this connection, if applicable.
+	OrigL2Addr         string       // orig_l2_addr:str Link-layer address of the originator, if available.
+	RespL2Addr         string       // resp_l2_addr:str Link-layer address of the responder, if available.
+	CommunityId        string       // community_id:str The community id hash of the connection's 5-tuple, if the community-id package is loaded.
+	IpProto            Opt[int]     // ip_proto:int The IP protocol number of the connection (6 for TCP, 17 for UDP, 1 for ICMP).
+	SpeculativeService string       // speculative_service:str Protocol that was determined by a matching signature after the beginning of a connection.
+	// ---------------
+	Extra map[string]string // any columns in the log that are not part of the schema above, keyed by field name
 }
 
 // ------------------------------
@@ -111,8 +120,6 @@ func thisLogEntryToConnStruct(givenLogEntry ZeekLogEntry, givenLogOpts *LogFileO
 		return
 	}
 
-	unimplementedFieldCount := 0
-
 	for _, thisField := range givenLogEntry {
 		switch thisField.fieldName {
 		case "ts":
@@ -139,106 +146,93 @@ func thisLogEntryToConnStruct(givenLogEntry ZeekLogEntry, givenLogOpts *LogFileO
 				return
 			}
 		case "proto":
-			if thisField.value == "udp" {
-				connEntry.Proto = UDP
-			} else if thisField.value == "tcp" {
-				connEntry.Proto = TCP
-			} else {
-				connEntry.Proto = NONE
-			}
+			connEntry.Proto = ParseProto(thisField.value)
 		case "service":
 			connEntry.Service = thisField.value
 		case "duration":
-			if thisField.value == givenLogOpts.unsetField {
-				connEntry.Duration = -1
-			} else {
-				connEntry.Duration, err = strconv.ParseFloat(thisField.value, 64)
-				if err != nil {
-					return
-				}
+			connEntry.Duration, err = optFloat(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
 			}
 		case "orig_bytes":
-			if thisField.value == givenLogOpts.unsetField {
-				connEntry.OrigBytes = -1
-			} else {
-				connEntry.OrigBytes, err = strconv.Atoi(thisField.value)
-				if err != nil {
-					return
-				}
+			connEntry.OrigBytes, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
 			}
 		case "resp_bytes":
-			if thisField.value == givenLogOpts.unsetField {
-				connEntry.RespBytes = -1
-			} else {
-				connEntry.RespBytes, err = strconv.Atoi(thisField.value)
-				if err != nil {
-					return
-				}
+			connEntry.RespBytes, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
 			}
 		case "conn_state":
 			thisConState := NewConnStateObj(thisField.value)
 			connEntry.ConnState = *thisConState
 		case "local_orig":
-			connEntry.LocalOrig = thisField.value == "T"
+			connEntry.LocalOrig = optBool(thisField.value)
 		case "local_resp":
-			connEntry.LocalResp = thisField.value == "T"
+			connEntry.LocalResp = optBool(thisField.value)
 		case "missed_bytes":
-			if thisField.value == givenLogOpts.unsetField {
-				connEntry.MissedBytes = -1
-			} else {
-				connEntry.MissedBytes, err = strconv.Atoi(thisField.value)
-				if err != nil {
-					return
-				}
+			connEntry.MissedBytes, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
 			}
 		case "history":
 			connEntry.History = thisField.value
 		case "orig_pkts":
-			if thisField.value == givenLogOpts.unsetField {
-				connEntry.OrigPkts = -1
-			} else {
-				connEntry.OrigPkts, err = strconv.Atoi(thisField.value)
-				if err != nil {
-					return
-				}
+			connEntry.OrigPkts, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
 			}
 		case "orig_ip_bytes":
-			if thisField.value == givenLogOpts.unsetField {
-				connEntry.OrigIpBytes = -1
-			} else {
-				connEntry.OrigIpBytes, err = strconv.Atoi(thisField.value)
-				if err != nil {
-					return
-				}
+			connEntry.OrigIpBytes, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
 			}
 		case "resp_pkts":
-			if thisField.value == givenLogOpts.unsetField {
-				connEntry.RespPkts = -1
-			} else {
-				connEntry.RespPkts, err = strconv.Atoi(thisField.value)
-				if err != nil {
-					return
-				}
+			connEntry.RespPkts, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
 			}
 		case "resp_ip_bytes":
-			if thisField.value == givenLogOpts.unsetField {
-				connEntry.RespIpBytes = -1
-			} else {
-				connEntry.RespIpBytes, err = strconv.Atoi(thisField.value)
-				if err != nil {
-					return
-				}
+			connEntry.RespIpBytes, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
 			}
 		case "tunnel_parents":
-			// TODO: this is unimplemented and that is fine.
+			connEntry.TunnelParents = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "vlan":
+			connEntry.Vlan, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "inner_vlan":
+			connEntry.InnerVlan, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "orig_l2_addr":
+			connEntry.OrigL2Addr = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "resp_l2_addr":
+			connEntry.RespL2Addr = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "community_id":
+			connEntry.CommunityId = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "ip_proto":
+			connEntry.IpProto, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "speculative_service":
+			connEntry.SpeculativeService = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
 		default:
-			log.Infof("unimplemented field: %s", thisField.fieldName)
-			unimplementedFieldCount++
+			if connEntry.Extra == nil {
+				connEntry.Extra = make(map[string]string)
+			}
+			connEntry.Extra[thisField.fieldName] = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
 		}
 	}
 
-	if unimplementedFieldCount > 0 {
-		log.Infof("this many unimplemented fields: %d", unimplementedFieldCount)
+	if len(connEntry.Extra) > 0 {
+		log.Debugf("this many extra fields kept: %d", len(connEntry.Extra))
 	}
 	return
 }
@@ -283,6 +277,6 @@ func ParseConnRecurse(givenDirectory string) (allResults []ConnEntry, err error)
 // parsed ConnEntry objects
 func GetAllConnForDay(givenDay string, givenZeekDir ...string) (allRes []ConnEntry, err error) {
 	zeekDir := GetZeekDir(givenZeekDir)
-	allRes, err = ParseConnRecurse( zeekDir + givenDay + "/")
+	allRes, err = ParseConnRecurse(zeekDir + givenDay + "/")
 	return
 }
@@ -0,0 +1,231 @@
+package zeekparse
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// conn history letters described in https://docs.zeek.org/en/current/scripts/base/protocols/conn/main.zeek.html#type-Conn::Info
+// upper case letters are from the originator and lower case from the responder.
+
+// ------------------------------
+// ----- Helper Structures ------
+// ------------------------------
+
+// HistoryEventType is what a single conn history letter records.
+type HistoryEventType string
+
+const (
+	HistorySyn          HistoryEventType = "SYN"          // s - a SYN without the ACK bit set
+	HistorySynAck       HistoryEventType = "SYN-ACK"      // h - a SYN+ACK ("handshake")
+	HistoryAck          HistoryEventType = "ACK"          // a - a pure ACK
+	HistoryData         HistoryEventType = "data"         // d - packet with payload
+	HistoryFin          HistoryEventType = "FIN"          // f - packet with FIN bit set
+	HistoryRst          HistoryEventType = "RST"          // r - packet with RST bit set
+	HistoryBadChecksum  HistoryEventType = "bad checksum" // c - packet with a bad checksum
+	HistoryContentGap   HistoryEventType = "content gap"  // g - a content gap
+	HistoryRetransmit   HistoryEventType = "retransmit"   // t - packet with retransmitted payload
+	HistoryZeroWindow   HistoryEventType = "zero window"  // w - packet with a zero window advertisement
+	HistoryInconsistent HistoryEventType = "inconsistent" // i - inconsistent packet (ie: SYN+RST bits set)
+	HistoryMultiFlag    HistoryEventType = "multi-flag"   // q - multi-flag packet (SYN+FIN or SYN+RST bits set)
+	HistoryFlipped      HistoryEventType = "flipped"      // ^ - connection direction was flipped by zeek's heuristic
+	HistoryUnknown      HistoryEventType = "unknown"      // any letter not listed above
+)
+
+// historyLetters maps the lower case history letters to what they record.
+var historyLetters = map[rune]HistoryEventType{
+	's': HistorySyn,
+	'h': HistorySynAck,
+	'a': HistoryAck,
+	'd': HistoryData,
+	'f': HistoryFin,
+	'r': HistoryRst,
+	'c': HistoryBadChecksum,
+	'g': HistoryContentGap,
+	't': HistoryRetransmit,
+	'w': HistoryZeroWindow,
+	'i': HistoryInconsistent,
+	'q': HistoryMultiFlag,
+	'^': HistoryFlipped,
+}
+
+// isScaledHistory tells if the given event is logged on an exponential scale, these letters repeat
+// when the count reaches 10, 100, 1000 and so on instead of being logged once.
+func isScaledHistory(givenType HistoryEventType) bool {
+	switch givenType {
+	case HistoryBadChecksum, HistoryContentGap, HistoryRetransmit, HistoryZeroWindow:
+		return true
+	}
+	return false
+}
+
+// HistoryEvent is a single decoded letter of a conn history.
+type HistoryEvent struct {
+	Letter   string           // the letter as logged
+	Type     HistoryEventType // what the letter records
+	FromOrig bool             // sent by the originator, false for the responder or a flip
+	Count    int              // how many times this event has been seen by this letter, repeats of scaled letters are 10, 100...
+}
+
+func (e HistoryEvent) String() string {
+	side := "resp"
+	if e.FromOrig {
+		side = "orig"
+	}
+	if e.Type == HistoryFlipped {
+		return string(e.Type)
+	}
+	if e.Count > 1 {
+		return fmt.Sprintf("%s %s x%d", side, e.Type, e.Count)
+	}
+	return fmt.Sprintf("%s %s", side, e.Type)
+}
+
+// ConnHistory is a decoded conn history string.
+type ConnHistory struct {
+	Raw    string
+	Events []HistoryEvent
+}
+
+// NewConnHistory decodes the given conn history string.  Unset or blank histories give no events
+// and letters zeek may add in future are kept as HistoryUnknown events.
+func NewConnHistory(givenHistory string) *ConnHistory {
+	h := new(ConnHistory)
+	h.Raw = givenHistory
+	if givenHistory == "-" {
+		return h
+	}
+	seen := make(map[string]int)
+	for _, thisLetter := range givenHistory {
+		event := HistoryEvent{
+			Letter:   string(thisLetter),
+			Type:     HistoryUnknown,
+			FromOrig: unicode.IsUpper(thisLetter),
+			Count:    1,
+		}
+		if thisType, ok := historyLetters[unicode.ToLower(thisLetter)]; ok {
+			event.Type = thisType
+		}
+		if isScaledHistory(event.Type) {
+			for idx := 0; idx < seen[event.Letter]; idx++ {
+				event.Count *= 10
+			}
+			seen[event.Letter]++
+		}
+		h.Events = append(h.Events, event)
+	}
+	return h
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (h *ConnHistory) Print() {
+	var allEvents []string
+	for _, thisEvent := range h.Events {
+		allEvents = append(allEvents, thisEvent.String())
+	}
+	fmt.Printf("%s: %s\n", h.Raw, strings.Join(allEvents, ", "))
+}
+
+// ------------------------------
+// ----    Entry Helpers   ------
+// ------------------------------
+
+// Saw tells if the given event was sent by the given side.
+func (h *ConnHistory) Saw(givenType HistoryEventType, givenFromOrig bool) bool {
+	return h.Count(givenType, givenFromOrig) > 0
+}
+
+// Count returns how many times the given event was seen from the given side.  Scaled events (bad
+// checksums, content gaps, retransmits and zero windows) are a lower bound as zeek only logs
+// them at each power of 10.
+func (h *ConnHistory) Count(givenType HistoryEventType, givenFromOrig bool) (count int) {
+	for _, thisEvent := range h.Events {
+		if thisEvent.Type == givenType && thisEvent.FromOrig == givenFromOrig {
+			if thisEvent.Count > count {
+				count = thisEvent.Count
+			}
+		}
+	}
+	return
+}
+
+// SawHandshake tells if the full three way handshake was seen, a SYN from the originator, a
+// SYN-ACK from the responder and then an ACK from the originator.
+func (h *ConnHistory) SawHandshake() bool {
+	step := 0
+	for _, thisEvent := range h.Events {
+		switch {
+		case step == 0 && thisEvent.Type == HistorySyn && thisEvent.FromOrig:
+			step++
+		case step == 1 && thisEvent.Type == HistorySynAck && !thisEvent.FromOrig:
+			step++
+		case step == 2 && thisEvent.Type == HistoryAck && thisEvent.FromOrig:
+			return true
+		}
+	}
+	return false
+}
+
+// Flipped tells if zeek flipped the originator and responder.
+func (h *ConnHistory) Flipped() bool {
+	return h.Saw(HistoryFlipped, false)
+}
+
+// OrigReset tells if the originator sent a RST.
+func (h *ConnHistory) OrigReset() bool {
+	return h.Saw(HistoryRst, true)
+}
+
+// RespReset tells if the responder sent a RST.
+func (h *ConnHistory) RespReset() bool {
+	return h.Saw(HistoryRst, false)
+}
+
+// OrigFin tells if the originator sent a FIN.
+func (h *ConnHistory) OrigFin() bool {
+	return h.Saw(HistoryFin, true)
+}
+
+// RespFin tells if the responder sent a FIN.
+func (h *ConnHistory) RespFin() bool {
+	return h.Saw(HistoryFin, false)
+}
+
+// OrigSentData tells if the originator sent any payload.
+func (h *ConnHistory) OrigSentData() bool {
+	return h.Saw(HistoryData, true)
+}
+
+// RespSentData tells if the responder sent any payload.
+func (h *ConnHistory) RespSentData() bool {
+	return h.Saw(HistoryData, false)
+}
+
+// HasRetransmits tells if either side retransmitted payload.
+func (h *ConnHistory) HasRetransmits() bool {
+	return h.Saw(HistoryRetransmit, true) || h.Saw(HistoryRetransmit, false)
+}
+
+// HasBadChecksums tells if either side sent a packet with a bad checksum.
+func (h *ConnHistory) HasBadChecksums() bool {
+	return h.Saw(HistoryBadChecksum, true) || h.Saw(HistoryBadChecksum, false)
+}
+
+// HasContentGaps tells if zeek saw a content gap in either direction.
+func (h *ConnHistory) HasContentGaps() bool {
+	return h.Saw(HistoryContentGap, true) || h.Saw(HistoryContentGap, false)
+}
+
+// HasZeroWindows tells if either side advertised a zero window.
+func (h *ConnHistory) HasZeroWindows() bool {
+	return h.Saw(HistoryZeroWindow, true) || h.Saw(HistoryZeroWindow, false)
+}
+
+// DecodeHistory decodes the history field of the connection.
+func (c *ConnEntry) DecodeHistory() *ConnHistory {
+	return NewConnHistory(c.History)
+}
@@ -0,0 +1,56 @@
+package zeekparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewConnHistory(t *testing.T) {
+	h := NewConnHistory("ShADadFf")
+	assert.Equal(t, 8, len(h.Events))
+	assert.Equal(t, HistoryEvent{Letter: "S", Type: HistorySyn, FromOrig: true, Count: 1}, h.Events[0])
+	assert.Equal(t, HistoryEvent{Letter: "h", Type: HistorySynAck, FromOrig: false, Count: 1}, h.Events[1])
+	assert.True(t, h.SawHandshake())
+	assert.True(t, h.OrigSentData())
+	assert.True(t, h.RespSentData())
+	assert.True(t, h.OrigFin())
+	assert.True(t, h.RespFin())
+	assert.False(t, h.OrigReset())
+	assert.False(t, h.RespReset())
+	assert.False(t, h.HasRetransmits())
+	assert.False(t, h.Flipped())
+
+	// no ack from the originator so the handshake never completed
+	h = NewConnHistory("ShR")
+	assert.False(t, h.SawHandshake())
+	assert.True(t, h.OrigReset())
+
+	// scaled letters repeat at each power of 10
+	h = NewConnHistory("ShADadTTTtwW")
+	assert.True(t, h.HasRetransmits())
+	assert.Equal(t, 100, h.Count(HistoryRetransmit, true))
+	assert.Equal(t, 1, h.Count(HistoryRetransmit, false))
+	assert.Equal(t, "orig retransmit x100", h.Events[8].String())
+	assert.True(t, h.HasZeroWindows())
+	assert.False(t, h.HasContentGaps())
+
+	h = NewConnHistory("^hCadCf")
+	assert.True(t, h.Flipped())
+	assert.True(t, h.HasBadChecksums())
+	assert.Equal(t, 10, h.Count(HistoryBadChecksum, true))
+	assert.Equal(t, "flipped", h.Events[0].String())
+
+	h = NewConnHistory("Sz")
+	assert.Equal(t, HistoryUnknown, h.Events[1].Type)
+
+	assert.Empty(t, NewConnHistory("-").Events)
+	assert.Empty(t, NewConnHistory("").Events)
+}
+
+func TestConnEntryDecodeHistory(t *testing.T) {
+	allConn, err := ParseConnLog("test_input/simple_conn_full.log")
+	assert.NoError(t, err)
+	assert.True(t, allConn[0].DecodeHistory().SawHandshake())
+	assert.Empty(t, allConn[1].DecodeHistory().Events)
+	assert.True(t, allConn[2].DecodeHistory().OrigSentData())
+}
@@ -0,0 +1,243 @@
+/*
+Typed connection endpoints.  Entries keep IdOrigH/IdRespH as the strings zeek logged and
+expose a ConnID built from them for subnet logic that works for both IPv4 and IPv6.
+*/
+
+package zeekparse
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// ConnID is the typed originator and responder of a connection.  Endpoints that zeek did
+// not log, or that don't parse as an address, are left as the zero netip.AddrPort.
+type ConnID struct {
+	Orig netip.AddrPort
+	Resp netip.AddrPort
+}
+
+// NewConnID builds a ConnID from the string and int endpoint fields found on every entry.
+func NewConnID(givenOrigH string, givenOrigP int, givenRespH string, givenRespP int) ConnID {
+	return ConnID{
+		Orig: toAddrPort(givenOrigH, givenOrigP),
+		Resp: toAddrPort(givenRespH, givenRespP),
+	}
+}
+
+// toAddrPort parses the given address and port, returning the zero netip.AddrPort if the
+// address doesn't parse.  Out of range ports become 0.
+func toAddrPort(givenAddress string, givenPort int) netip.AddrPort {
+	addr, err := netip.ParseAddr(givenAddress)
+	if err != nil {
+		return netip.AddrPort{}
+	}
+	if givenPort < 0 || givenPort > 65535 {
+		givenPort = 0
+	}
+	return netip.AddrPortFrom(addr.Unmap(), uint16(givenPort))
+}
+
+// ParsePrefixes parses the given CIDR strings into prefixes.
+func ParsePrefixes(givenPrefixes ...string) (prefixes []netip.Prefix, err error) {
+	for _, thisPrefix := range givenPrefixes {
+		var parsed netip.Prefix
+		parsed, err = netip.ParsePrefix(thisPrefix)
+		if err != nil {
+			return
+		}
+		prefixes = append(prefixes, parsed.Masked())
+	}
+	return
+}
+
+// addrInPrefixes tells if the given address is within any of the given prefixes.
+func addrInPrefixes(givenAddr netip.Addr, givenPrefixes []netip.Prefix) bool {
+	if !givenAddr.IsValid() {
+		return false
+	}
+	for _, thisPrefix := range givenPrefixes {
+		if thisPrefix.Contains(givenAddr) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c ConnID) String() string {
+	return fmt.Sprintf("%s -> %s", c.Orig, c.Resp)
+}
+
+// IsValid tells if both endpoints were logged with a parsable address.
+func (c ConnID) IsValid() bool {
+	return c.Orig.Addr().IsValid() && c.Resp.Addr().IsValid()
+}
+
+// OrigInPrefix tells if the originator is within any of the given prefixes.
+func (c ConnID) OrigInPrefix(givenPrefixes ...netip.Prefix) bool {
+	return addrInPrefixes(c.Orig.Addr(), givenPrefixes)
+}
+
+// RespInPrefix tells if the responder is within any of the given prefixes.
+func (c ConnID) RespInPrefix(givenPrefixes ...netip.Prefix) bool {
+	return addrInPrefixes(c.Resp.Addr(), givenPrefixes)
+}
+
+// InPrefix tells if either endpoint is within any of the given prefixes.
+func (c ConnID) InPrefix(givenPrefixes ...netip.Prefix) bool {
+	return c.OrigInPrefix(givenPrefixes...) || c.RespInPrefix(givenPrefixes...)
+}
+
+// OrigIsPrivate tells if the originator is a private (RFC 1918 or RFC 4193) address.
+func (c ConnID) OrigIsPrivate() bool {
+	return c.Orig.Addr().IsPrivate()
+}
+
+// RespIsPrivate tells if the responder is a private (RFC 1918 or RFC 4193) address.
+func (c ConnID) RespIsPrivate() bool {
+	return c.Resp.Addr().IsPrivate()
+}
+
+// IsPrivate tells if both endpoints are private addresses.
+func (c ConnID) IsPrivate() bool {
+	return c.OrigIsPrivate() && c.RespIsPrivate()
+}
+
+// ------------------------------
+// ----    Entry Accessors   ----
+// ------------------------------
+
+// ConnID returns the typed endpoints of the connection.
+func (c *ConnEntry) ConnID() ConnID {
+	return NewConnID(c.IdOrigH, c.IdOrigP, c.IdRespH, c.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the dns request.
+func (thisEntry *DnsEntry) ConnID() ConnID {
+	return NewConnID(thisEntry.IdOrigH, thisEntry.IdOrigP, thisEntry.IdRespH, thisEntry.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the http request.
+func (thisEntry *HttpEntry) ConnID() ConnID {
+	return NewConnID(thisEntry.IdOrigH, thisEntry.IdOrigP, thisEntry.IdRespH, thisEntry.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the ssl connection.
+func (s *SSLEntry) ConnID() ConnID {
+	return NewConnID(s.IdOrigH, s.IdOrigP, s.IdRespH, s.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the dce_rpc call.
+func (d *DceRpcEntry) ConnID() ConnID {
+	return NewConnID(d.IdOrigH, d.IdOrigP, d.IdRespH, d.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the dnp3 request.
+func (d *Dnp3Entry) ConnID() ConnID {
+	return NewConnID(d.IdOrigH, d.IdOrigP, d.IdRespH, d.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the connection that failed protocol analysis.
+func (d *DpdEntry) ConnID() ConnID {
+	return NewConnID(d.IdOrigH, d.IdOrigP, d.IdRespH, d.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the ftp session.
+func (f *FtpEntry) ConnID() ConnID {
+	return NewConnID(f.IdOrigH, f.IdOrigP, f.IdRespH, f.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the connection the indicator was seen on.
+func (i *IntelEntry) ConnID() ConnID {
+	return NewConnID(i.IdOrigH, i.IdOrigP, i.IdRespH, i.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the irc session.
+func (i *IrcEntry) ConnID() ConnID {
+	return NewConnID(i.IdOrigH, i.IdOrigP, i.IdRespH, i.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the kerberos request.
+func (k *KerberosEntry) ConnID() ConnID {
+	return NewConnID(k.IdOrigH, k.IdOrigP, k.IdRespH, k.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the modbus request.
+func (m *ModbusEntry) ConnID() ConnID {
+	return NewConnID(m.IdOrigH, m.IdOrigP, m.IdRespH, m.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the mysql command.
+func (m *MysqlEntry) ConnID() ConnID {
+	return NewConnID(m.IdOrigH, m.IdOrigP, m.IdRespH, m.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the ntlm authentication.
+func (n *NtlmEntry) ConnID() ConnID {
+	return NewConnID(n.IdOrigH, n.IdOrigP, n.IdRespH, n.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the ntp message.
+func (n *NtpEntry) ConnID() ConnID {
+	return NewConnID(n.IdOrigH, n.IdOrigP, n.IdRespH, n.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the quic connection.
+func (q *QuicEntry) ConnID() ConnID {
+	return NewConnID(q.IdOrigH, q.IdOrigP, q.IdRespH, q.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the radius authentication.
+func (r *RadiusEntry) ConnID() ConnID {
+	return NewConnID(r.IdOrigH, r.IdOrigP, r.IdRespH, r.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the rdp session.
+func (r *RdpEntry) ConnID() ConnID {
+	return NewConnID(r.IdOrigH, r.IdOrigP, r.IdRespH, r.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the sip request.
+func (s *SipEntry) ConnID() ConnID {
+	return NewConnID(s.IdOrigH, s.IdOrigP, s.IdRespH, s.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the smb file access.
+func (s *SmbFilesEntry) ConnID() ConnID {
+	return NewConnID(s.IdOrigH, s.IdOrigP, s.IdRespH, s.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the smb tree mapping.
+func (s *SmbMappingEntry) ConnID() ConnID {
+	return NewConnID(s.IdOrigH, s.IdOrigP, s.IdRespH, s.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the smtp transaction.
+func (s *SmtpEntry) ConnID() ConnID {
+	return NewConnID(s.IdOrigH, s.IdOrigP, s.IdRespH, s.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the snmp session.
+func (s *SnmpEntry) ConnID() ConnID {
+	return NewConnID(s.IdOrigH, s.IdOrigP, s.IdRespH, s.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the socks request.
+func (s *SocksEntry) ConnID() ConnID {
+	return NewConnID(s.IdOrigH, s.IdOrigP, s.IdRespH, s.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the syslog message.
+func (s *SyslogEntry) ConnID() ConnID {
+	return NewConnID(s.IdOrigH, s.IdOrigP, s.IdRespH, s.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the tunnel.
+func (t *TunnelEntry) ConnID() ConnID {
+	return NewConnID(t.IdOrigH, t.IdOrigP, t.IdRespH, t.IdRespP)
+}
+
+// ConnID returns the typed endpoints of the weird, ports are 0 if zeek did not log them.
+func (w *WeirdEntry) ConnID() ConnID {
+	return NewConnID(w.IdOrigH, w.IdOrigP.Value(), w.IdRespH, w.IdRespP.Value())
+}
@@ -0,0 +1,62 @@
+package zeekparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/netip"
+	"testing"
+)
+
+func TestNewConnID(t *testing.T) {
+	id := NewConnID("192.168.1.110", 51515, "93.184.216.34", 443)
+	assert.True(t, id.IsValid())
+	assert.Equal(t, netip.MustParseAddrPort("192.168.1.110:51515"), id.Orig)
+	assert.Equal(t, uint16(443), id.Resp.Port())
+	assert.Equal(t, "192.168.1.110:51515 -> 93.184.216.34:443", id.String())
+	assert.True(t, id.OrigIsPrivate())
+	assert.False(t, id.RespIsPrivate())
+	assert.False(t, id.IsPrivate())
+
+	// blank addresses and out of range ports
+	blank := NewConnID("", -1, "", -1)
+	assert.False(t, blank.IsValid())
+	assert.False(t, blank.InPrefix(netip.MustParsePrefix("0.0.0.0/0")))
+
+	v6 := NewConnID("2001:db8::5", 40000, "fd00::53", 53)
+	assert.True(t, v6.RespIsPrivate())
+	assert.False(t, v6.OrigIsPrivate())
+}
+
+func TestConnIDInPrefix(t *testing.T) {
+	prefixes, err := ParsePrefixes("192.168.0.0/23", "2001:db8::/32")
+	assert.NoError(t, err)
+
+	id := NewConnID("192.168.1.110", 51515, "93.184.216.34", 443)
+	assert.True(t, id.OrigInPrefix(prefixes...))
+	assert.False(t, id.RespInPrefix(prefixes...))
+	assert.True(t, id.InPrefix(prefixes...))
+
+	v6 := NewConnID("2001:db8::5", 40000, "2001:db8::53", 53)
+	assert.True(t, v6.OrigInPrefix(prefixes...))
+	assert.True(t, v6.RespInPrefix(prefixes...))
+
+	_, err = ParsePrefixes("not a cidr")
+	assert.Error(t, err)
+}
+
+func TestEntryConnID(t *testing.T) {
+	allConn, err := ParseConnLog("test_input/simple_conn_full.log")
+	assert.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddr("2001:db8::53"), allConn[2].ConnID().Resp.Addr())
+
+	allDns, err := ParseDNSLog("test_input/simple_dns_full.log")
+	assert.NoError(t, err)
+	assert.True(t, allDns[0].ConnID().IsPrivate())
+
+	allHttp, err := ParseHttpLog("test_input/simple_http_full.log")
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(80), allHttp[0].ConnID().Resp.Port())
+
+	allSSL, err := ParseSSLLog("test_input/simple_ssl_full.log")
+	assert.NoError(t, err)
+	assert.Equal(t, "192.168.1.110:52001 -> 93.184.216.34:443", allSSL[0].ConnID().String())
+}
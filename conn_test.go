@@ -40,3 +40,30 @@ func TestParseConnLog(t *testing.T) {
 	_, err := ParseConnLog("test_input/simple_conn.log.gz")
 	assert.NoError(t, err)
 }
+
+func TestParseConnLogFullSchema(t *testing.T) {
+	allConn, err := ParseConnLog("test_input/simple_conn_full.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(allConn))
+
+	assert.Equal(t, TCP, allConn[0].Proto)
+	assert.Equal(t, Some(100), allConn[0].Vlan)
+	assert.False(t, allConn[0].InnerVlan.IsSet())
+	assert.Equal(t, "00:11:22:33:44:55", allConn[0].OrigL2Addr)
+	assert.Equal(t, "66:77:88:99:aa:bb", allConn[0].RespL2Addr)
+	assert.Equal(t, "1:LQU9qZlK+B5F3KDmev6m5PMibrg=", allConn[0].CommunityId)
+	assert.Equal(t, Some(6), allConn[0].IpProto)
+	assert.Equal(t, "", allConn[0].SpeculativeService)
+	assert.Nil(t, allConn[0].TunnelParents)
+	assert.Equal(t, map[string]string{"sensor_name": "sensor-a"}, allConn[0].Extra)
+
+	assert.Equal(t, ICMP, allConn[1].Proto)
+	assert.Equal(t, Some(200), allConn[1].InnerVlan)
+	assert.Equal(t, Some(1), allConn[1].IpProto)
+
+	assert.Equal(t, UDP, allConn[2].Proto)
+	assert.Equal(t, []string{"CTun1a2b3c4d5e6f7g", "CTun2b3c4d5e6f7g1a"}, allConn[2].TunnelParents)
+	assert.False(t, allConn[2].Vlan.IsSet())
+	assert.Equal(t, "dns", allConn[2].SpeculativeService)
+	assert.Equal(t, "", allConn[2].Extra["sensor_name"])
+}
@@ -0,0 +1,128 @@
+/*
+Scans the supported logs for credentials that crossed the wire in cleartext.  Currently
+this covers ftp.log logins, http.log basic-auth usernames and socks.log proxy logins.
+*/
+
+package zeekparse
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CleartextCredential is a username (and password if it was logged) seen in cleartext.
+type CleartextCredential struct {
+	TS       time.Time // TS:time - timestamp of the first time the credential was seen on the connection
+	Uid      string    // Uid:string - unique id of the connection
+	IdOrigH  string    // id_orig_h:addr - client address
+	IdRespH  string    // id_resp_h:addr - server address
+	IdRespP  int       // id_resp_p:port - server port
+	Protocol string    // protocol the credential was seen in (ie: ftp, http, socks)
+	Username string    // username that was sent
+	Password string    // password that was sent, blank if zeek did not log it
+}
+
+func (c *CleartextCredential) Print() {
+	fmt.Printf("[%s] %s %s -> %s:%d user:%s\n",
+		c.TS, c.Protocol, c.IdOrigH, c.IdRespH, c.IdRespP, c.Username)
+}
+
+// ftp users that are anonymous logins rather than real credentials.
+var anonymousFtpUsers = map[string]bool{
+	"anonymous": true,
+	"ftp":       true,
+	"<unknown>": true,
+}
+
+// CredentialLogs are the parsed logs FindCleartextCredentials scans, leave a log nil to skip it.
+type CredentialLogs struct {
+	Ftp   []FtpEntry
+	Http  []HttpEntry
+	Socks []SocksEntry
+}
+
+// FindCleartextCredentials returns one CleartextCredential per connection and username seen in
+// the given logs, sorted by time.  Anonymous ftp logins are not reported.
+func FindCleartextCredentials(givenLogs CredentialLogs) (allCreds []CleartextCredential) {
+	seen := make(map[string]bool)
+	addCred := func(givenCred CleartextCredential) {
+		key := givenCred.Uid + "|" + givenCred.Username
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		allCreds = append(allCreds, givenCred)
+	}
+
+	for _, thisFtp := range givenLogs.Ftp {
+		if len(thisFtp.User) == 0 || anonymousFtpUsers[strings.ToLower(thisFtp.User)] {
+			continue
+		}
+		addCred(CleartextCredential{
+			TS:       thisFtp.TS,
+			Uid:      thisFtp.Uid,
+			IdOrigH:  thisFtp.IdOrigH,
+			IdRespH:  thisFtp.IdRespH,
+			IdRespP:  thisFtp.IdRespP,
+			Protocol: "ftp",
+			Username: thisFtp.User,
+			Password: thisFtp.Password,
+		})
+	}
+
+	for _, thisHttp := range givenLogs.Http {
+		if len(thisHttp.Username) == 0 {
+			continue
+		}
+		addCred(CleartextCredential{
+			TS:       thisHttp.TS,
+			Uid:      thisHttp.Uid,
+			IdOrigH:  thisHttp.IdOrigH,
+			IdRespH:  thisHttp.IdRespH,
+			IdRespP:  thisHttp.IdRespP,
+			Protocol: "http",
+			Username: thisHttp.Username,
+			Password: thisHttp.Password,
+		})
+	}
+
+	for _, thisSocks := range givenLogs.Socks {
+		if len(thisSocks.User) == 0 {
+			continue
+		}
+		addCred(CleartextCredential{
+			TS:       thisSocks.TS,
+			Uid:      thisSocks.Uid,
+			IdOrigH:  thisSocks.IdOrigH,
+			IdRespH:  thisSocks.IdRespH,
+			IdRespP:  thisSocks.IdRespP,
+			Protocol: "socks",
+			Username: thisSocks.User,
+			Password: thisSocks.Password,
+		})
+	}
+
+	sort.SliceStable(allCreds, func(i, j int) bool {
+		return allCreds[i].TS.Before(allCreds[j].TS)
+	})
+	return
+}
+
+// GetAllCleartextCredentialsForDay parses the ftp, http and socks logs on the given day from the default
+// zeek directory and returns the cleartext credentials found in them.
+func GetAllCleartextCredentialsForDay(givenDay string, givenZeekDir ...string) (allCreds []CleartextCredential, err error) {
+	var allLogs CredentialLogs
+	if allLogs.Ftp, err = GetAllFtpForDay(givenDay, givenZeekDir...); err != nil {
+		return
+	}
+	if allLogs.Http, err = GetAllHttpForDay(givenDay, givenZeekDir...); err != nil {
+		return
+	}
+	if allLogs.Socks, err = GetAllSocksForDay(givenDay, givenZeekDir...); err != nil {
+		return
+	}
+	allCreds = FindCleartextCredentials(allLogs)
+	return
+}
@@ -0,0 +1,36 @@
+package zeekparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFindCleartextCredentials(t *testing.T) {
+	allFtp, err := ParseFtpLog("test_input/simple_ftp.log")
+	assert.NoError(t, err)
+
+	allHttp := []HttpEntry{
+		{Uid: "CHttp1a2b3c4d5e6f7", IdOrigH: "192.168.1.110", IdRespH: "192.168.1.1", IdRespP: 80, Username: "admin"},
+		{Uid: "CHttp1a2b3c4d5e6f7", IdOrigH: "192.168.1.110", IdRespH: "192.168.1.1", IdRespP: 80, Username: "admin"},
+		{Uid: "CHttp2a2b3c4d5e6f7", IdOrigH: "192.168.1.110", IdRespH: "192.168.1.1", IdRespP: 80},
+	}
+
+	// anonymous ftp is skipped and repeated commands on one session are reported once
+	creds := FindCleartextCredentials(CredentialLogs{Ftp: allFtp, Http: allHttp})
+	assert.Equal(t, 2, len(creds))
+	assert.Equal(t, "http", creds[0].Protocol)
+	assert.Equal(t, "ftp", creds[1].Protocol)
+	assert.Equal(t, "backup", creds[1].Username)
+
+	// socks proxy logins, the connect without a login is skipped
+	allSocks, err := ParseSocksLog("test_input/simple_socks.log")
+	assert.NoError(t, err)
+	creds = FindCleartextCredentials(CredentialLogs{Socks: allSocks})
+	assert.Equal(t, 2, len(creds))
+	assert.Equal(t, "bob", creds[0].Username)
+	assert.Equal(t, "", creds[0].Password)
+	assert.Equal(t, "socks", creds[1].Protocol)
+	assert.Equal(t, "svc-backup", creds[1].Username)
+	assert.Equal(t, "hunter2", creds[1].Password)
+	assert.Equal(t, 1080, creds[1].IdRespP)
+}
@@ -0,0 +1,137 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"time"
+)
+
+// dce_rpc log format described in https://docs.zeek.org/en/master/scripts/base/protocols/dce-rpc/main.zeek.html#type-DCE_RPC::Info
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// DceRpcEntry is a fully parsed dce_rpc.log line.
+type DceRpcEntry struct {
+	TS      time.Time // TS:time - timestamp
+	Uid     string    // Uid:string - unique id
+	IdOrigH string    // id_orig_h:addr - senders address
+	IdOrigP int       // id_orig_p:addr - senders port
+	IdRespH string    // id_resp_h:port - responders address
+	IdRespP int       // id_resp_p:port - responders port
+	// ---------
+	RTT       Opt[float64] // rtt:interval - round trip time from the request to the response
+	NamedPipe string       // named_pipe:string - remote pipe name
+	Endpoint  string       // endpoint:string - endpoint name looked up from the uuid
+	Operation string       // operation:string - operation seen in the call
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (d *DceRpcEntry) Print() {
+	fmt.Printf("(%s) client {%s:%d} talks to {%s:%d}:\n",
+		d.TS.String(), d.IdOrigH, d.IdOrigP, d.IdRespH, d.IdRespP)
+	fmt.Printf("\t%s %s::%s\n", d.NamedPipe, d.Endpoint, d.Operation)
+}
+
+func (d *DceRpcEntry) ShortPrint() {
+	fmt.Printf("[%s] %s -> %s %s::%s\n", d.TS, d.IdOrigH, d.IdRespH, d.Endpoint, d.Operation)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToDceRpcStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (dceRpcEntry DceRpcEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			dceRpcEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "uid":
+			dceRpcEntry.Uid = thisField.value
+		case "id.orig_h":
+			dceRpcEntry.IdOrigH = thisField.value
+		case "id.orig_p":
+			dceRpcEntry.IdOrigP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id.resp_h":
+			dceRpcEntry.IdRespH = thisField.value
+		case "id.resp_p":
+			dceRpcEntry.IdRespP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "rtt":
+			dceRpcEntry.RTT, err = optFloat(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "named_pipe":
+			dceRpcEntry.NamedPipe = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "endpoint":
+			dceRpcEntry.Endpoint = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "operation":
+			dceRpcEntry.Operation = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseDceRpcLog will parse through the given single dce_rpc log (passed as a filename string)
+func ParseDceRpcLog(givenFilename string) (parsedResults []DceRpcEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes DceRpcEntry
+		thisRes, err = thisLogEntryToDceRpcStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseDceRpcRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseDceRpcRecurse(givenDirectory string) (allResults []DceRpcEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "dce_rpc") {
+		thisResult, parseErr := ParseDceRpcLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllDceRpcForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed DceRpcEntry objects
+func GetAllDceRpcForDay(givenDay string, givenZeekDir ...string) (allRes []DceRpcEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseDceRpcRecurse(zeekDir + givenDay + "/")
+	return
+}
@@ -0,0 +1,28 @@
+package zeekparse
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThisLogEntryToDceRpcStruct(t *testing.T) {
+	log.SetFormatter(&log.TextFormatter{ForceColors: true})
+	log.SetLevel(log.InfoLevel)
+
+	// compressed case
+	compressedResults, header, compErr := parseZeekLog("test_input/simple_dce_rpc.log.gz")
+	for _, thisResult := range compressedResults {
+		_, parseErr := thisLogEntryToDceRpcStruct(thisResult, header)
+		assert.NoError(t, parseErr)
+	}
+	assert.NoError(t, compErr)
+}
+
+func TestParseDceRpcLog(t *testing.T) {
+	allRpc, err := ParseDceRpcLog("test_input/simple_dce_rpc.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(allRpc))
+	assert.Equal(t, "NetrShareEnum", allRpc[0].Operation)
+	assert.False(t, allRpc[1].RTT.IsSet())
+}
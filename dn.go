@@ -0,0 +1,257 @@
+/*
+Distinguished name parsing.  zeek logs x509 subjects and issuers as RFC 4514 strings (ie:
+CN=*.google.com,O=Google LLC,C=US) where commas and other specials inside a value are
+escaped with a backslash or as \XX hex.
+*/
+
+package zeekparse
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ------------------------------
+// ----- Helper Structures ------
+// ------------------------------
+
+// DNAttribute is a single type=value pair from a distinguished name.
+type DNAttribute struct {
+	Type  string // attribute type as logged (ie: CN, emailAddress or an OID)
+	Value string // unescaped value
+}
+
+// DistinguishedName is a parsed distinguished name with its attributes in the order logged.
+// Multi-valued RDNs (joined by +) are flattened into the one list.
+type DistinguishedName struct {
+	Raw        string
+	Attributes []DNAttribute
+}
+
+// dnTypeAliases maps the other names and OIDs an attribute type can be logged as to the
+// short name used by the helpers below.
+var dnTypeAliases = map[string]string{
+	"commonname":             "CN",
+	"2.5.4.3":                "CN",
+	"organizationname":       "O",
+	"2.5.4.10":               "O",
+	"organizationalunitname": "OU",
+	"2.5.4.11":               "OU",
+	"countryname":            "C",
+	"2.5.4.6":                "C",
+	"localityname":           "L",
+	"2.5.4.7":                "L",
+	"stateorprovincename":    "ST",
+	"s":                      "ST",
+	"2.5.4.8":                "ST",
+	"emailaddress":           "EMAILADDRESS",
+	"email":                  "EMAILADDRESS",
+	"e":                      "EMAILADDRESS",
+	"1.2.840.113549.1.9.1":   "EMAILADDRESS",
+}
+
+// normaliseDNType returns the upper case short name for the given attribute type.
+func normaliseDNType(givenType string) string {
+	givenType = strings.TrimSpace(givenType)
+	if alias, ok := dnTypeAliases[strings.TrimPrefix(strings.ToLower(givenType), "oid.")]; ok {
+		return alias
+	}
+	return strings.ToUpper(givenType)
+}
+
+// ParseDN parses the given RFC 4514 distinguished name.  Values may use backslash escapes for
+// special characters, \XX hex escapes for UTF-8 bytes or be double quoted as older
+// RFC 1779 names are.  An empty string gives a DistinguishedName with no attributes.
+func ParseDN(givenDN string) (dn DistinguishedName, err error) {
+	dn.Raw = givenDN
+	if len(strings.TrimSpace(givenDN)) == 0 {
+		return
+	}
+	pos := 0
+	for pos <= len(givenDN) {
+		var attr DNAttribute
+		attr, pos, err = parseDNAttribute(givenDN, pos)
+		if err != nil {
+			return DistinguishedName{Raw: givenDN}, err
+		}
+		dn.Attributes = append(dn.Attributes, attr)
+		if pos >= len(givenDN) {
+			break
+		}
+		// skip the , ; or + separator
+		pos++
+	}
+	return
+}
+
+// parseDNAttribute parses a single type=value starting at the given position and returns the
+// position of the separator after it (or the end of the string).
+func parseDNAttribute(givenDN string, givenPos int) (attr DNAttribute, pos int, err error) {
+	eq := strings.IndexByte(givenDN[givenPos:], '=')
+	if eq < 0 {
+		err = fmt.Errorf("dn attribute at %d has no '='", givenPos)
+		return
+	}
+	attr.Type = strings.TrimSpace(givenDN[givenPos : givenPos+eq])
+	if len(attr.Type) == 0 || strings.ContainsAny(attr.Type, ",+;") {
+		err = fmt.Errorf("dn attribute at %d has no type", givenPos)
+		return
+	}
+	pos = givenPos + eq + 1
+	for pos < len(givenDN) && givenDN[pos] == ' ' {
+		pos++
+	}
+
+	var value []byte
+	keep := 0 // length of value up to the last escaped or non-space char, trailing spaces must be escaped
+	quoted := pos < len(givenDN) && givenDN[pos] == '"'
+	if quoted {
+		pos++
+	}
+	for ; pos < len(givenDN); pos++ {
+		thisChar := givenDN[pos]
+		switch {
+		case thisChar == '\\':
+			if pos+1 >= len(givenDN) {
+				err = errors.New("dn ends with an unfinished escape")
+				return
+			}
+			if pos+2 < len(givenDN) && isHexByte(givenDN[pos+1:pos+3]) {
+				decoded, _ := hex.DecodeString(givenDN[pos+1 : pos+3])
+				value = append(value, decoded...)
+				pos += 2
+			} else {
+				value = append(value, givenDN[pos+1])
+				pos++
+			}
+			keep = len(value)
+		case quoted && thisChar == '"':
+			quoted = false
+			keep = len(value)
+		case quoted:
+			value = append(value, thisChar)
+			keep = len(value)
+		case thisChar == ',' || thisChar == ';' || thisChar == '+':
+			attr.Value = string(value[:keep])
+			return
+		default:
+			value = append(value, thisChar)
+			if thisChar != ' ' {
+				keep = len(value)
+			}
+		}
+	}
+	if quoted {
+		err = errors.New("dn has an unterminated quoted value")
+		return
+	}
+	attr.Value = string(value[:keep])
+	return
+}
+
+// isHexByte tells if the given two chars are a hex encoded byte.
+func isHexByte(givenChars string) bool {
+	_, err := hex.DecodeString(givenChars)
+	return err == nil
+}
+
+// ------------------------------
+// ----    Entry Helpers   ------
+// ------------------------------
+
+func (d DistinguishedName) String() string {
+	return d.Raw
+}
+
+// GetAll returns every value of the given attribute type (ie: OU), matching the type ignoring
+// case and by its long name or OID.
+func (d DistinguishedName) GetAll(givenType string) (values []string) {
+	wanted := normaliseDNType(givenType)
+	for _, thisAttr := range d.Attributes {
+		if normaliseDNType(thisAttr.Type) == wanted {
+			values = append(values, thisAttr.Value)
+		}
+	}
+	return
+}
+
+// Get returns the first value of the given attribute type, blank if not present.
+func (d DistinguishedName) Get(givenType string) string {
+	if values := d.GetAll(givenType); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// CommonName returns the CN of the name.
+func (d DistinguishedName) CommonName() string {
+	return d.Get("CN")
+}
+
+// Organization returns the O of the name.
+func (d DistinguishedName) Organization() string {
+	return d.Get("O")
+}
+
+// OrganizationalUnits returns all OUs of the name.
+func (d DistinguishedName) OrganizationalUnits() []string {
+	return d.GetAll("OU")
+}
+
+// Country returns the C of the name.
+func (d DistinguishedName) Country() string {
+	return d.Get("C")
+}
+
+// Locality returns the L of the name.
+func (d DistinguishedName) Locality() string {
+	return d.Get("L")
+}
+
+// State returns the ST of the name.
+func (d DistinguishedName) State() string {
+	return d.Get("ST")
+}
+
+// EmailAddress returns the emailAddress of the name.
+func (d DistinguishedName) EmailAddress() string {
+	return d.Get("emailAddress")
+}
+
+// Equal tells if the two names have the same attributes in the same order, comparing types by
+// their short name and values ignoring case.
+func (d DistinguishedName) Equal(givenDN DistinguishedName) bool {
+	if len(d.Attributes) != len(givenDN.Attributes) {
+		return false
+	}
+	for idx, thisAttr := range d.Attributes {
+		theirAttr := givenDN.Attributes[idx]
+		if normaliseDNType(thisAttr.Type) != normaliseDNType(theirAttr.Type) ||
+			!strings.EqualFold(thisAttr.Value, theirAttr.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameDN tells if the two raw names are the same name, falling back to comparing the raw
+// strings if either doesn't parse.
+func sameDN(givenA string, givenB string) bool {
+	dnA, errA := ParseDN(givenA)
+	dnB, errB := ParseDN(givenB)
+	if errA != nil || errB != nil {
+		return givenA == givenB
+	}
+	return dnA.Equal(dnB)
+}
+
+// commonNameOf returns the CN of the given raw name, blank if it doesn't parse.
+func commonNameOf(givenDN string) string {
+	dn, err := ParseDN(givenDN)
+	if err != nil {
+		return ""
+	}
+	return dn.CommonName()
+}
@@ -0,0 +1,69 @@
+package zeekparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseDN(t *testing.T) {
+	dn, err := ParseDN("CN=*.google.com,O=Google LLC,L=Mountain View,ST=California,C=US")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, len(dn.Attributes))
+	assert.Equal(t, "*.google.com", dn.CommonName())
+	assert.Equal(t, "Google LLC", dn.Organization())
+	assert.Equal(t, "Mountain View", dn.Locality())
+	assert.Equal(t, "California", dn.State())
+	assert.Equal(t, "US", dn.Country())
+
+	// escaped specials, hex escapes and multiple OUs
+	dn, err = ParseDN(`CN=Smith\, John,OU=Sales\+Marketing,OU=EMEA,O=Caf\C3\A9 \"Bleu\",emailAddress=john@example.com`)
+	assert.NoError(t, err)
+	assert.Equal(t, "Smith, John", dn.CommonName())
+	assert.Equal(t, []string{"Sales+Marketing", "EMEA"}, dn.OrganizationalUnits())
+	assert.Equal(t, `Café "Bleu"`, dn.Organization())
+	assert.Equal(t, "john@example.com", dn.EmailAddress())
+	assert.Equal(t, "john@example.com", dn.Get("1.2.840.113549.1.9.1"))
+
+	// multi-valued rdns, spaces around separators, quoted values and escaped trailing space
+	dn, err = ParseDN(`CN=host+UID=42, O = "Acme, Inc" ,OU=trailing\ `)
+	assert.NoError(t, err)
+	assert.Equal(t, []DNAttribute{{"CN", "host"}, {"UID", "42"}, {"O", "Acme, Inc"}, {"OU", "trailing "}}, dn.Attributes)
+
+	dn, err = ParseDN("")
+	assert.NoError(t, err)
+	assert.Empty(t, dn.Attributes)
+	assert.Equal(t, "", dn.CommonName())
+
+	for _, thisBad := range []string{"CN", "CN=a,", "=a", `CN=a\`, `CN="a`} {
+		_, err = ParseDN(thisBad)
+		assert.Error(t, err, thisBad)
+	}
+}
+
+func TestDNEqual(t *testing.T) {
+	a, _ := ParseDN("CN=Corp Device CA,O=Corp")
+	b, _ := ParseDN("commonName=corp device ca, O=Corp")
+	c, _ := ParseDN("O=Corp,CN=Corp Device CA")
+	assert.True(t, a.Equal(b))
+	assert.False(t, a.Equal(c))
+}
+
+func TestCertSelfSigned(t *testing.T) {
+	allX509, err := ParseX509Log("test_input/simple_x509_fingerprint.log")
+	assert.NoError(t, err)
+	for _, thisX509 := range allX509 {
+		assert.False(t, thisX509.IsSelfSigned())
+	}
+	assert.Equal(t, "laptop-17", allX509[len(allX509)-1].CommonName())
+
+	selfSigned := X509Entry{CertSubject: "CN=router.lan,O=Home", CertIssuer: "CN=router.lan, O=Home"}
+	assert.True(t, selfSigned.IsSelfSigned())
+	assert.False(t, (&X509Entry{}).IsSelfSigned())
+
+	ssl := SSLEntry{ServerSubject: `CN=Smith\, John`, ServerIssuer: `CN=Smith\2C John`}
+	assert.True(t, ssl.ServerSelfSigned())
+	assert.Equal(t, "Smith, John", ssl.ServerCommonName())
+	issuer, err := ssl.ServerIssuerDN()
+	assert.NoError(t, err)
+	assert.Equal(t, "Smith, John", issuer.CommonName())
+}
@@ -0,0 +1,323 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dnp3 log format described in https://docs.zeek.org/en/master/scripts/base/protocols/dnp3/main.zeek.html#type-DNP3::Info
+// function codes and internal indications from IEEE 1815
+
+// ------------------------------
+// ----- Helper Structures ------
+// ------------------------------
+
+// Dnp3Function is a dnp3 application layer function code.
+type Dnp3Function uint8
+
+const (
+	Dnp3Confirm             Dnp3Function = 0
+	Dnp3Read                Dnp3Function = 1
+	Dnp3Write               Dnp3Function = 2
+	Dnp3Select              Dnp3Function = 3
+	Dnp3Operate             Dnp3Function = 4
+	Dnp3DirectOperate       Dnp3Function = 5
+	Dnp3DirectOperateNr     Dnp3Function = 6
+	Dnp3ImmedFreeze         Dnp3Function = 7
+	Dnp3ImmedFreezeNr       Dnp3Function = 8
+	Dnp3FreezeClear         Dnp3Function = 9
+	Dnp3FreezeClearNr       Dnp3Function = 10
+	Dnp3FreezeAtTime        Dnp3Function = 11
+	Dnp3FreezeAtTimeNr      Dnp3Function = 12
+	Dnp3ColdRestart         Dnp3Function = 13
+	Dnp3WarmRestart         Dnp3Function = 14
+	Dnp3InitializeData      Dnp3Function = 15
+	Dnp3InitializeAppl      Dnp3Function = 16
+	Dnp3StartAppl           Dnp3Function = 17
+	Dnp3StopAppl            Dnp3Function = 18
+	Dnp3SaveConfig          Dnp3Function = 19
+	Dnp3EnableUnsolicited   Dnp3Function = 20
+	Dnp3DisableUnsolicited  Dnp3Function = 21
+	Dnp3AssignClass         Dnp3Function = 22
+	Dnp3DelayMeasure        Dnp3Function = 23
+	Dnp3RecordCurrentTime   Dnp3Function = 24
+	Dnp3OpenFile            Dnp3Function = 25
+	Dnp3CloseFile           Dnp3Function = 26
+	Dnp3DeleteFile          Dnp3Function = 27
+	Dnp3GetFileInfo         Dnp3Function = 28
+	Dnp3AuthenticateFile    Dnp3Function = 29
+	Dnp3AbortFile           Dnp3Function = 30
+	Dnp3ActivateConfig      Dnp3Function = 31
+	Dnp3AuthenticateReq     Dnp3Function = 32
+	Dnp3AuthenticateErr     Dnp3Function = 33
+	Dnp3Response            Dnp3Function = 129
+	Dnp3UnsolicitedResponse Dnp3Function = 130
+	Dnp3AuthenticateResp    Dnp3Function = 131
+)
+
+// dnp3 function names as zeek logs them
+var dnp3FunctionNames = map[Dnp3Function]string{
+	Dnp3Confirm:             "CONFIRM",
+	Dnp3Read:                "READ",
+	Dnp3Write:               "WRITE",
+	Dnp3Select:              "SELECT",
+	Dnp3Operate:             "OPERATE",
+	Dnp3DirectOperate:       "DIRECT_OPERATE",
+	Dnp3DirectOperateNr:     "DIRECT_OPERATE_NR",
+	Dnp3ImmedFreeze:         "IMMED_FREEZE",
+	Dnp3ImmedFreezeNr:       "IMMED_FREEZE_NR",
+	Dnp3FreezeClear:         "FREEZE_CLEAR",
+	Dnp3FreezeClearNr:       "FREEZE_CLEAR_NR",
+	Dnp3FreezeAtTime:        "FREEZE_AT_TIME",
+	Dnp3FreezeAtTimeNr:      "FREEZE_AT_TIME_NR",
+	Dnp3ColdRestart:         "COLD_RESTART",
+	Dnp3WarmRestart:         "WARM_RESTART",
+	Dnp3InitializeData:      "INITIALIZE_DATA",
+	Dnp3InitializeAppl:      "INITIALIZE_APPL",
+	Dnp3StartAppl:           "START_APPL",
+	Dnp3StopAppl:            "STOP_APPL",
+	Dnp3SaveConfig:          "SAVE_CONFIG",
+	Dnp3EnableUnsolicited:   "ENABLE_UNSOLICITED",
+	Dnp3DisableUnsolicited:  "DISABLE_UNSOLICITED",
+	Dnp3AssignClass:         "ASSIGN_CLASS",
+	Dnp3DelayMeasure:        "DELAY_MEASURE",
+	Dnp3RecordCurrentTime:   "RECORD_CURRENT_TIME",
+	Dnp3OpenFile:            "OPEN_FILE",
+	Dnp3CloseFile:           "CLOSE_FILE",
+	Dnp3DeleteFile:          "DELETE_FILE",
+	Dnp3GetFileInfo:         "GET_FILE_INFO",
+	Dnp3AuthenticateFile:    "AUTHENTICATE_FILE",
+	Dnp3AbortFile:           "ABORT_FILE",
+	Dnp3ActivateConfig:      "ACTIVATE_CONFIG",
+	Dnp3AuthenticateReq:     "AUTHENTICATE_REQ",
+	Dnp3AuthenticateErr:     "AUTHENTICATE_ERR",
+	Dnp3Response:            "RESPONSE",
+	Dnp3UnsolicitedResponse: "UNSOLICITED_RESPONSE",
+	Dnp3AuthenticateResp:    "AUTHENTICATE_RESP",
+}
+
+// String returns the function name the same way zeek logs it.
+func (f Dnp3Function) String() string {
+	if name, ok := dnp3FunctionNames[f]; ok {
+		return name
+	}
+	return "unknown-" + strconv.Itoa(int(f))
+}
+
+// IsControl tells if the function operates outputs, restarts the outstation or changes its
+// configuration rather than just reading from it.
+func (f Dnp3Function) IsControl() bool {
+	switch f {
+	case Dnp3Write, Dnp3Select, Dnp3Operate, Dnp3DirectOperate, Dnp3DirectOperateNr,
+		Dnp3ColdRestart, Dnp3WarmRestart, Dnp3InitializeData, Dnp3InitializeAppl, Dnp3StartAppl,
+		Dnp3StopAppl, Dnp3SaveConfig, Dnp3ActivateConfig, Dnp3DeleteFile:
+		return true
+	}
+	return false
+}
+
+// ParseDnp3Function converts a function name as logged by zeek back into its code.
+func ParseDnp3Function(givenName string) (Dnp3Function, error) {
+	if strings.HasPrefix(givenName, "unknown-") {
+		code, err := strconv.ParseUint(strings.TrimPrefix(givenName, "unknown-"), 10, 8)
+		return Dnp3Function(code), err
+	}
+	for code, name := range dnp3FunctionNames {
+		if name == givenName {
+			return code, nil
+		}
+	}
+	return 0, errors.New("unknown dnp3 function: " + givenName)
+}
+
+// Dnp3IIN is the internal indications field of a dnp3 response.  Zeek logs it as a single
+// count with the first octet (IIN1) in the high byte and the second octet (IIN2) in the low byte.
+type Dnp3IIN uint16
+
+// internal indication bits in the order they appear in Flags
+var dnp3IINFlags = []struct {
+	bit     Dnp3IIN
+	name    string
+	isError bool
+}{
+	{1 << 8, "ALL_STATIONS", false},
+	{1 << 9, "CLASS_1_EVENTS", false},
+	{1 << 10, "CLASS_2_EVENTS", false},
+	{1 << 11, "CLASS_3_EVENTS", false},
+	{1 << 12, "NEED_TIME", false},
+	{1 << 13, "LOCAL_CONTROL", false},
+	{1 << 14, "DEVICE_TROUBLE", true},
+	{1 << 15, "DEVICE_RESTART", false},
+	{1 << 0, "NO_FUNC_CODE_SUPPORT", true},
+	{1 << 1, "OBJECT_UNKNOWN", true},
+	{1 << 2, "PARAMETER_ERROR", true},
+	{1 << 3, "EVENT_BUFFER_OVERFLOW", true},
+	{1 << 4, "ALREADY_EXECUTING", true},
+	{1 << 5, "CONFIG_CORRUPT", true},
+}
+
+// Flags returns the names of all the internal indications that are set.
+func (i Dnp3IIN) Flags() (flags []string) {
+	for _, thisFlag := range dnp3IINFlags {
+		if i&thisFlag.bit != 0 {
+			flags = append(flags, thisFlag.name)
+		}
+	}
+	return
+}
+
+// Errors returns the names of the internal indications that report the outstation failed to
+// carry out the request or is in trouble.
+func (i Dnp3IIN) Errors() (errs []string) {
+	for _, thisFlag := range dnp3IINFlags {
+		if thisFlag.isError && i&thisFlag.bit != 0 {
+			errs = append(errs, thisFlag.name)
+		}
+	}
+	return
+}
+
+// IsException tells if any of the error indications are set.
+func (i Dnp3IIN) IsException() bool {
+	return len(i.Errors()) > 0
+}
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// Dnp3Entry is a fully parsed dnp3.log line.
+type Dnp3Entry struct {
+	TS      time.Time // TS:time - timestamp
+	Uid     string    // Uid:string - unique id
+	IdOrigH string    // id_orig_h:addr - senders address
+	IdOrigP int       // id_orig_p:addr - senders port
+	IdRespH string    // id_resp_h:port - responders address
+	IdRespP int       // id_resp_p:port - responders port
+	// ---------
+	FcRequest     Dnp3Function // fc_request:string - the function code of the request
+	FcRequestName string       // fc_request:string - the name of the request function code as logged
+	FcReply       Dnp3Function // fc_reply:string - the function code of the reply
+	FcReplyName   string       // fc_reply:string - the name of the reply function code as logged
+	IIN           Dnp3IIN      // iin:count - the response's internal indication number, 0 if unset
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (d *Dnp3Entry) Print() {
+	fmt.Printf("(%s) master {%s:%d} talks to outstation {%s:%d}:\n",
+		d.TS.String(), d.IdOrigH, d.IdOrigP, d.IdRespH, d.IdRespP)
+	fmt.Printf("\t%s -> %s iin:%s\n", d.FcRequestName, d.FcReplyName, d.IIN.Flags())
+}
+
+func (d *Dnp3Entry) ShortPrint() {
+	fmt.Printf("[%s] %s -> %s %s %s\n", d.TS, d.IdOrigH, d.IdRespH, d.FcRequestName, d.FcReplyName)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToDnp3Struct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (dnp3Entry Dnp3Entry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			dnp3Entry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "uid":
+			dnp3Entry.Uid = thisField.value
+		case "id.orig_h":
+			dnp3Entry.IdOrigH = thisField.value
+		case "id.orig_p":
+			dnp3Entry.IdOrigP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id.resp_h":
+			dnp3Entry.IdRespH = thisField.value
+		case "id.resp_p":
+			dnp3Entry.IdRespP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "fc_request":
+			dnp3Entry.FcRequestName = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+			if len(dnp3Entry.FcRequestName) > 0 {
+				// unrecognised names are still kept in FcRequestName
+				dnp3Entry.FcRequest, _ = ParseDnp3Function(dnp3Entry.FcRequestName)
+			}
+		case "fc_reply":
+			dnp3Entry.FcReplyName = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+			if len(dnp3Entry.FcReplyName) > 0 {
+				dnp3Entry.FcReply, _ = ParseDnp3Function(dnp3Entry.FcReplyName)
+			}
+		case "iin":
+			if thisField.value != givenLogOpts.unsetField {
+				var iin uint64
+				iin, err = strconv.ParseUint(thisField.value, 10, 16)
+				if err != nil {
+					return
+				}
+				dnp3Entry.IIN = Dnp3IIN(iin)
+			}
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseDnp3Log will parse through the given single dnp3 log (passed as a filename string)
+func ParseDnp3Log(givenFilename string) (parsedResults []Dnp3Entry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes Dnp3Entry
+		thisRes, err = thisLogEntryToDnp3Struct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseDnp3Recurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseDnp3Recurse(givenDirectory string) (allResults []Dnp3Entry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "dnp3") {
+		thisResult, parseErr := ParseDnp3Log(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllDnp3ForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed Dnp3Entry objects
+func GetAllDnp3ForDay(givenDay string, givenZeekDir ...string) (allRes []Dnp3Entry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseDnp3Recurse(zeekDir + givenDay + "/")
+	return
+}
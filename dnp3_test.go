@@ -0,0 +1,37 @@
+package zeekparse
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThisLogEntryToDnp3Struct(t *testing.T) {
+	log.SetFormatter(&log.TextFormatter{ForceColors: true})
+	log.SetLevel(log.InfoLevel)
+
+	// compressed case
+	compressedResults, header, compErr := parseZeekLog("test_input/simple_dnp3.log.gz")
+	for _, thisResult := range compressedResults {
+		_, parseErr := thisLogEntryToDnp3Struct(thisResult, header)
+		assert.NoError(t, parseErr)
+	}
+	assert.NoError(t, compErr)
+}
+
+func TestDnp3IIN(t *testing.T) {
+	allDnp3, err := ParseDnp3Log("test_input/simple_dnp3.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(allDnp3))
+
+	assert.Equal(t, Dnp3Read, allDnp3[0].FcRequest)
+	assert.Equal(t, Dnp3Response, allDnp3[0].FcReply)
+	assert.Equal(t, []string{"CLASS_1_EVENTS", "DEVICE_RESTART"}, allDnp3[0].IIN.Flags())
+	assert.False(t, allDnp3[0].IIN.IsException())
+
+	assert.True(t, allDnp3[1].FcRequest.IsControl())
+	assert.Equal(t, []string{"PARAMETER_ERROR"}, allDnp3[1].IIN.Errors())
+
+	assert.Equal(t, "", allDnp3[2].FcRequestName)
+	assert.Equal(t, Dnp3UnsolicitedResponse, allDnp3[2].FcReply)
+}
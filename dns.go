@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	log "github.com/sirupsen/logrus"
+	"net"
 	"strconv"
 	"strings"
 	"time"
@@ -20,6 +21,28 @@ import (
 // description of common DNS fields: https://www.zytrax.com/books/dns/ch15/
 // ------------
 
+// ------------------------------
+// ----- Helper Structures ------
+// ------------------------------
+
+// DnsAnswerType is what kind of value a single dns answer string holds.
+type DnsAnswerType string
+
+const (
+	DnsAnswerA     DnsAnswerType = "A"
+	DnsAnswerAAAA  DnsAnswerType = "AAAA"
+	DnsAnswerCNAME DnsAnswerType = "CNAME"
+	DnsAnswerPTR   DnsAnswerType = "PTR"
+	DnsAnswerOther DnsAnswerType = "OTHER"
+)
+
+// DnsAnswer is a single answer from a dns.log line along with its type and TTL.
+type DnsAnswer struct {
+	Value string
+	Type  DnsAnswerType
+	TTL   Opt[float64] // unset if zeek did not log a TTL for this answer
+}
+
 // ------------------------------
 // ------ Entry Structure -------
 // ------------------------------
@@ -34,23 +57,28 @@ type DnsEntry struct {
 	IdRespP int       // id_resp_p:port - responders port
 	Proto   Proto     // Proto:enum - protocol
 	// ---------------
-	TransId    int       // trans_id:count - identifier assigned by the program that generated the Query.
-	RTT        float64   // RTT:int - round trip time for Query + resp
-	Query      string    // Query:string  - the Query
-	QClass     int       // QClass:count - QCLASS field in the question section
-	QClassName string    // qclass_name:string - descriptive name of the QCLASS
-	QType      int       // QType:count - type of record being requested (value)
-	QTypeName  string    // qtype_name:string - rtype of record being requested (descriptive string)
-	RCode      int       // RCode:count - response being returned (value)
-	RCodeName  string    // rcode_name:string - response being returned (descriptive string)
-	AA         bool      // AA:bool - authorative response (set by responder)?
-	TC         bool      // TC:bool - truncated response (set by responder?
-	RD         bool      // RD:bool - recursion desired (by sender)?
-	RA         bool      // RA:bool - recursion available (set by responder)
-	Z          int       // Z:count - reserved field (usually 0)
-	Answers    []string  // Answers:vector[string] - all Answers
-	TTLs       []float64 // TTLs:vector[interval] - vector of TTL of the responses lifespan in cache
-	Rejected   bool      // Rejected:bool - Rejected by server?
+	TransId       int            // trans_id:count - identifier assigned by the program that generated the Query.
+	RTT           Opt[float64]   // RTT:int - round trip time for Query + resp
+	Query         string         // Query:string  - the Query
+	QClass        Opt[DnsQClass] // QClass:count - QCLASS field in the question section
+	QClassName    string         // qclass_name:string - descriptive name of the QCLASS
+	QType         Opt[DnsQType]  // QType:count - type of record being requested (value)
+	QTypeName     string         // qtype_name:string - rtype of record being requested (descriptive string)
+	RCode         Opt[DnsRCode]  // RCode:count - response being returned (value)
+	RCodeName     string         // rcode_name:string - response being returned (descriptive string)
+	AA            bool           // AA:bool - authorative response (set by responder)?
+	TC            bool           // TC:bool - truncated response (set by responder?
+	RD            bool           // RD:bool - recursion desired (by sender)?
+	RA            bool           // RA:bool - recursion available (set by responder)
+	Z             Opt[int]       // Z:count - reserved field (usually 0)
+	Answers       []string       // Answers:vector[string] - all Answers, nil if unset
+	TTLs          []float64      // TTLs:vector[interval] - vector of TTL of the responses lifespan in cache, nil if unset
+	Rejected      bool           // Rejected:bool - Rejected by server?
+	Auth          []string       // auth:set[string] - authoritative responses for the query, nil if unset
+	Addl          []string       // addl:set[string] - additional responses for the query, nil if unset
+	OriginalQuery string         // original_query:string - the query before any case or IDN normalisation
+	// ---------------
+	Extra map[string]string // any columns in the log that are not part of the schema above, keyed by field name
 }
 
 // ------------------------------
@@ -73,6 +101,97 @@ func (thisEntry *DnsEntry) IsRDNSLookup() bool {
 	return strings.HasSuffix(thisEntry.Query, ".in-addr.arpa")
 }
 
+// classifyDnsAnswer works out what kind of value the given answer is.  Hostname answers are
+// told apart using the query type since zeek logs CNAME, PTR, MX and NS targets the same way.
+func classifyDnsAnswer(givenAnswer string, givenQTypeName string) DnsAnswerType {
+	if ip := net.ParseIP(givenAnswer); ip != nil {
+		if ip.To4() != nil {
+			return DnsAnswerA
+		}
+		return DnsAnswerAAAA
+	}
+	if len(givenAnswer) == 0 || strings.ContainsAny(givenAnswer, " \t") {
+		return DnsAnswerOther
+	}
+	switch givenQTypeName {
+	case "PTR":
+		return DnsAnswerPTR
+	case "A", "AAAA", "CNAME":
+		return DnsAnswerCNAME
+	}
+	return DnsAnswerOther
+}
+
+// ClassifiedAnswers returns each answer with its type and TTL.
+func (thisEntry *DnsEntry) ClassifiedAnswers() (answers []DnsAnswer) {
+	for i, thisAnswer := range thisEntry.Answers {
+		thisClassified := DnsAnswer{Value: thisAnswer, Type: classifyDnsAnswer(thisAnswer, thisEntry.QTypeName)}
+		if i < len(thisEntry.TTLs) {
+			thisClassified.TTL = Some(thisEntry.TTLs[i])
+		}
+		answers = append(answers, thisClassified)
+	}
+	return
+}
+
+// AnswersOfType returns only the answers of the given type.
+func (thisEntry *DnsEntry) AnswersOfType(givenType DnsAnswerType) (answers []string) {
+	for _, thisAnswer := range thisEntry.ClassifiedAnswers() {
+		if thisAnswer.Type == givenType {
+			answers = append(answers, thisAnswer.Value)
+		}
+	}
+	return
+}
+
+// fillDnsCodes fills in whichever of the numeric and name columns zeek did not log so the
+// typed codes and their names are always both available.
+func (thisEntry *DnsEntry) fillDnsCodes() {
+	if !thisEntry.QClass.IsSet() {
+		if class, ok := DnsQClassByName(thisEntry.QClassName); ok {
+			thisEntry.QClass = Some(class)
+		}
+	} else if len(thisEntry.QClassName) == 0 {
+		thisEntry.QClassName = thisEntry.QClass.Value().String()
+	}
+	if !thisEntry.QType.IsSet() {
+		if qtype, ok := DnsQTypeByName(thisEntry.QTypeName); ok {
+			thisEntry.QType = Some(qtype)
+		}
+	} else if len(thisEntry.QTypeName) == 0 {
+		thisEntry.QTypeName = thisEntry.QType.Value().String()
+	}
+	if !thisEntry.RCode.IsSet() {
+		if rcode, ok := DnsRCodeByName(thisEntry.RCodeName); ok {
+			thisEntry.RCode = Some(rcode)
+		}
+	} else if len(thisEntry.RCodeName) == 0 {
+		thisEntry.RCodeName = thisEntry.RCode.Value().String()
+	}
+}
+
+// IsNXDomain tells if the response was NXDOMAIN (the name does not exist).
+func (thisEntry *DnsEntry) IsNXDomain() bool {
+	rcode, ok := thisEntry.RCode.Get()
+	return ok && rcode.IsNXDomain()
+}
+
+// IsServFail tells if the response was SERVFAIL.
+func (thisEntry *DnsEntry) IsServFail() bool {
+	rcode, ok := thisEntry.RCode.Get()
+	return ok && rcode.IsServFail()
+}
+
+// FilterDnsByRCode returns only the given entries answered with the given response code.
+func FilterDnsByRCode(givenEntries []DnsEntry, givenRCode DnsRCode) (filtered []DnsEntry) {
+	for _, thisEntry := range givenEntries {
+		if thisEntry.RCode == Some(givenRCode) {
+			filtered = append(filtered, thisEntry)
+		}
+	}
+	return
+}
+
 // ------------------------------
 // ---- Main Parse Function -----
 // ------------------------------
@@ -111,60 +230,40 @@ func thisLogEntryToDNSStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFi
 				return
 			}
 		case "proto":
-			if thisField.value == "udp" {
-				DNSEntry.Proto = UDP
-			} else if thisField.value == "tcp" {
-				DNSEntry.Proto = TCP
-			}
+			DNSEntry.Proto = ParseProto(thisField.value)
 		case "trans_id":
 			DNSEntry.TransId, err = strconv.Atoi(thisField.value)
 			if err != nil {
 				return
 			}
 		case "rtt":
-			if thisField.value == givenLogOpts.unsetField {
-				DNSEntry.RTT = -1
-			} else {
-				DNSEntry.RTT, err = strconv.ParseFloat(thisField.value, 64)
-				if err != nil {
-					return
-				}
+			DNSEntry.RTT, err = optFloat(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
 			}
 		case "query":
 			DNSEntry.Query = thisField.value
 		case "qclass":
-			if thisField.value == givenLogOpts.unsetField {
-				DNSEntry.QClass = -1
-			} else {
-				DNSEntry.QClass, err = strconv.Atoi(thisField.value)
-				if err != nil {
-					return
-				}
+			DNSEntry.QClass, err = optDnsCode[DnsQClass](thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
 			}
 		case "qclass_name":
-			DNSEntry.QClassName = thisField.value
+			DNSEntry.QClassName = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
 		case "qtype":
-			if thisField.value == givenLogOpts.unsetField {
-				DNSEntry.QType = -1
-			} else {
-				DNSEntry.QType, err = strconv.Atoi(thisField.value)
-				if err != nil {
-					return
-				}
+			DNSEntry.QType, err = optDnsCode[DnsQType](thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
 			}
 		case "qtype_name":
-			DNSEntry.QTypeName = thisField.value
+			DNSEntry.QTypeName = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
 		case "rcode":
-			if thisField.value == givenLogOpts.unsetField {
-				DNSEntry.RCode = -1
-			} else {
-				DNSEntry.RCode, err = strconv.Atoi(thisField.value)
-				if err != nil {
-					return
-				}
+			DNSEntry.RCode, err = optDnsCode[DnsRCode](thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
 			}
 		case "rcode_name":
-			DNSEntry.RCodeName = thisField.value
+			DNSEntry.RCodeName = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
 		case "AA":
 			DNSEntry.AA = thisField.value == "T"
 		case "TC":
@@ -176,39 +275,35 @@ func thisLogEntryToDNSStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFi
 		case "rejected":
 			DNSEntry.Rejected = thisField.value == "T"
 		case "Z":
-			if thisField.value == givenLogOpts.unsetField {
-				DNSEntry.Z = -1
-			} else {
-				DNSEntry.Z, err = strconv.Atoi(thisField.value)
-				if err != nil {
-					return
-				}
+			DNSEntry.Z, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
 			}
 		case "answers":
-			if thisField.value == givenLogOpts.unsetField {
-				DNSEntry.Answers = append(DNSEntry.Answers, "")
-			} else {
-				splitSlice := strings.Split(thisField.value, givenLogOpts.setSeparator)
-				DNSEntry.Answers = splitSlice
-			}
+			DNSEntry.Answers = strSliceNilIfUnset(thisField.value, givenLogOpts)
 		case "TTLs":
-			if thisField.value == givenLogOpts.unsetField {
-				DNSEntry.TTLs = append(DNSEntry.TTLs, -1)
-			} else {
-				splitSlice := strings.Split(thisField.value, givenLogOpts.setSeparator)
-				for _, thisEntry := range splitSlice {
-					var thisFloat float64
-					thisFloat, err = strconv.ParseFloat(thisEntry, 64)
-					if err != nil {
-						return
-					}
-					DNSEntry.TTLs = append(DNSEntry.TTLs, thisFloat)
+			for _, thisEntry := range strSliceNilIfUnset(thisField.value, givenLogOpts) {
+				var thisFloat float64
+				thisFloat, err = strconv.ParseFloat(thisEntry, 64)
+				if err != nil {
+					return
 				}
+				DNSEntry.TTLs = append(DNSEntry.TTLs, thisFloat)
 			}
+		case "auth":
+			DNSEntry.Auth = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "addl":
+			DNSEntry.Addl = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "original_query":
+			DNSEntry.OriginalQuery = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
 		default:
-			log.Infof("unimplemented field: %s", thisField.fieldName)
+			if DNSEntry.Extra == nil {
+				DNSEntry.Extra = make(map[string]string)
+			}
+			DNSEntry.Extra[thisField.fieldName] = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
 		}
 	}
+	DNSEntry.fillDnsCodes()
 	return
 }
 
@@ -0,0 +1,323 @@
+/*
+Typed dns qtype, qclass and rcode values.  Names follow the IANA DNS parameters registry
+(https://www.iana.org/assignments/dns-parameters/dns-parameters.xhtml) and zeek's own
+names in base/protocols/dns/consts.zeek so a DnsEntry can be filled in from either column.
+*/
+
+package zeekparse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ------------------------------
+// ----- Helper Structures ------
+// ------------------------------
+
+// DnsQType is a dns resource record type.
+type DnsQType uint16
+
+const (
+	DnsTypeA          DnsQType = 1
+	DnsTypeNS         DnsQType = 2
+	DnsTypeMD         DnsQType = 3
+	DnsTypeMF         DnsQType = 4
+	DnsTypeCNAME      DnsQType = 5
+	DnsTypeSOA        DnsQType = 6
+	DnsTypeMB         DnsQType = 7
+	DnsTypeMG         DnsQType = 8
+	DnsTypeMR         DnsQType = 9
+	DnsTypeNULL       DnsQType = 10
+	DnsTypeWKS        DnsQType = 11
+	DnsTypePTR        DnsQType = 12
+	DnsTypeHINFO      DnsQType = 13
+	DnsTypeMINFO      DnsQType = 14
+	DnsTypeMX         DnsQType = 15
+	DnsTypeTXT        DnsQType = 16
+	DnsTypeRP         DnsQType = 17
+	DnsTypeAFSDB      DnsQType = 18
+	DnsTypeX25        DnsQType = 19
+	DnsTypeISDN       DnsQType = 20
+	DnsTypeRT         DnsQType = 21
+	DnsTypeNSAP       DnsQType = 22
+	DnsTypeNSAPPTR    DnsQType = 23
+	DnsTypeSIG        DnsQType = 24
+	DnsTypeKEY        DnsQType = 25
+	DnsTypePX         DnsQType = 26
+	DnsTypeGPOS       DnsQType = 27
+	DnsTypeAAAA       DnsQType = 28
+	DnsTypeLOC        DnsQType = 29
+	DnsTypeNXT        DnsQType = 30
+	DnsTypeEID        DnsQType = 31
+	DnsTypeNIMLOC     DnsQType = 32
+	DnsTypeSRV        DnsQType = 33
+	DnsTypeATMA       DnsQType = 34
+	DnsTypeNAPTR      DnsQType = 35
+	DnsTypeKX         DnsQType = 36
+	DnsTypeCERT       DnsQType = 37
+	DnsTypeA6         DnsQType = 38
+	DnsTypeDNAME      DnsQType = 39
+	DnsTypeSINK       DnsQType = 40
+	DnsTypeOPT        DnsQType = 41
+	DnsTypeAPL        DnsQType = 42
+	DnsTypeDS         DnsQType = 43
+	DnsTypeSSHFP      DnsQType = 44
+	DnsTypeIPSECKEY   DnsQType = 45
+	DnsTypeRRSIG      DnsQType = 46
+	DnsTypeNSEC       DnsQType = 47
+	DnsTypeDNSKEY     DnsQType = 48
+	DnsTypeDHCID      DnsQType = 49
+	DnsTypeNSEC3      DnsQType = 50
+	DnsTypeNSEC3PARAM DnsQType = 51
+	DnsTypeTLSA       DnsQType = 52
+	DnsTypeSMIMEA     DnsQType = 53
+	DnsTypeHIP        DnsQType = 55
+	DnsTypeNINFO      DnsQType = 56
+	DnsTypeRKEY       DnsQType = 57
+	DnsTypeTALINK     DnsQType = 58
+	DnsTypeCDS        DnsQType = 59
+	DnsTypeCDNSKEY    DnsQType = 60
+	DnsTypeOPENPGPKEY DnsQType = 61
+	DnsTypeCSYNC      DnsQType = 62
+	DnsTypeZONEMD     DnsQType = 63
+	DnsTypeSVCB       DnsQType = 64
+	DnsTypeHTTPS      DnsQType = 65
+	DnsTypeSPF        DnsQType = 99
+	DnsTypeUINFO      DnsQType = 100
+	DnsTypeUID        DnsQType = 101
+	DnsTypeGID        DnsQType = 102
+	DnsTypeUNSPEC     DnsQType = 103
+	DnsTypeNID        DnsQType = 104
+	DnsTypeL32        DnsQType = 105
+	DnsTypeL64        DnsQType = 106
+	DnsTypeLP         DnsQType = 107
+	DnsTypeEUI48      DnsQType = 108
+	DnsTypeEUI64      DnsQType = 109
+	DnsTypeTKEY       DnsQType = 249
+	DnsTypeTSIG       DnsQType = 250
+	DnsTypeIXFR       DnsQType = 251
+	DnsTypeAXFR       DnsQType = 252
+	DnsTypeMAILB      DnsQType = 253
+	DnsTypeMAILA      DnsQType = 254
+	DnsTypeANY        DnsQType = 255
+	DnsTypeURI        DnsQType = 256
+	DnsTypeCAA        DnsQType = 257
+	DnsTypeAVC        DnsQType = 258
+	DnsTypeDOA        DnsQType = 259
+	DnsTypeAMTRELAY   DnsQType = 260
+	DnsTypeTA         DnsQType = 32768
+	DnsTypeDLV        DnsQType = 32769
+)
+
+var dnsQTypeNames = map[DnsQType]string{
+	DnsTypeA: "A", DnsTypeNS: "NS", DnsTypeMD: "MD", DnsTypeMF: "MF", DnsTypeCNAME: "CNAME",
+	DnsTypeSOA: "SOA", DnsTypeMB: "MB", DnsTypeMG: "MG", DnsTypeMR: "MR", DnsTypeNULL: "NULL",
+	DnsTypeWKS: "WKS", DnsTypePTR: "PTR", DnsTypeHINFO: "HINFO", DnsTypeMINFO: "MINFO",
+	DnsTypeMX: "MX", DnsTypeTXT: "TXT", DnsTypeRP: "RP", DnsTypeAFSDB: "AFSDB", DnsTypeX25: "X25",
+	DnsTypeISDN: "ISDN", DnsTypeRT: "RT", DnsTypeNSAP: "NSAP", DnsTypeNSAPPTR: "NSAP-PTR",
+	DnsTypeSIG: "SIG", DnsTypeKEY: "KEY", DnsTypePX: "PX", DnsTypeGPOS: "GPOS", DnsTypeAAAA: "AAAA",
+	DnsTypeLOC: "LOC", DnsTypeNXT: "NXT", DnsTypeEID: "EID", DnsTypeNIMLOC: "NIMLOC",
+	DnsTypeSRV: "SRV", DnsTypeATMA: "ATMA", DnsTypeNAPTR: "NAPTR", DnsTypeKX: "KX",
+	DnsTypeCERT: "CERT", DnsTypeA6: "A6", DnsTypeDNAME: "DNAME", DnsTypeSINK: "SINK",
+	DnsTypeOPT: "OPT", DnsTypeAPL: "APL", DnsTypeDS: "DS", DnsTypeSSHFP: "SSHFP",
+	DnsTypeIPSECKEY: "IPSECKEY", DnsTypeRRSIG: "RRSIG", DnsTypeNSEC: "NSEC", DnsTypeDNSKEY: "DNSKEY",
+	DnsTypeDHCID: "DHCID", DnsTypeNSEC3: "NSEC3", DnsTypeNSEC3PARAM: "NSEC3PARAM",
+	DnsTypeTLSA: "TLSA", DnsTypeSMIMEA: "SMIMEA", DnsTypeHIP: "HIP", DnsTypeNINFO: "NINFO",
+	DnsTypeRKEY: "RKEY", DnsTypeTALINK: "TALINK", DnsTypeCDS: "CDS", DnsTypeCDNSKEY: "CDNSKEY",
+	DnsTypeOPENPGPKEY: "OPENPGPKEY", DnsTypeCSYNC: "CSYNC", DnsTypeZONEMD: "ZONEMD",
+	DnsTypeSVCB: "SVCB", DnsTypeHTTPS: "HTTPS", DnsTypeSPF: "SPF", DnsTypeUINFO: "UINFO",
+	DnsTypeUID: "UID", DnsTypeGID: "GID", DnsTypeUNSPEC: "UNSPEC", DnsTypeNID: "NID",
+	DnsTypeL32: "L32", DnsTypeL64: "L64", DnsTypeLP: "LP", DnsTypeEUI48: "EUI48",
+	DnsTypeEUI64: "EUI64", DnsTypeTKEY: "TKEY", DnsTypeTSIG: "TSIG", DnsTypeIXFR: "IXFR",
+	DnsTypeAXFR: "AXFR", DnsTypeMAILB: "MAILB", DnsTypeMAILA: "MAILA", DnsTypeANY: "*",
+	DnsTypeURI: "URI", DnsTypeCAA: "CAA", DnsTypeAVC: "AVC", DnsTypeDOA: "DOA",
+	DnsTypeAMTRELAY: "AMTRELAY", DnsTypeTA: "TA", DnsTypeDLV: "DLV",
+}
+
+// String returns the name zeek logs for the type (ie: AAAA), "*" for ANY and query-N for
+// types not in the registry.
+func (q DnsQType) String() string {
+	if name, ok := dnsQTypeNames[q]; ok {
+		return name
+	}
+	return fmt.Sprintf("query-%d", q)
+}
+
+// DnsQTypeByName returns the type for the given name as logged in qtype_name.  Names are
+// matched ignoring case and "ANY", query-N and TYPEN are understood as well.
+func DnsQTypeByName(givenName string) (DnsQType, bool) {
+	if strings.EqualFold(givenName, "ANY") {
+		return DnsTypeANY, true
+	}
+	return dnsCodeByName(givenName, dnsQTypeNames, "query-", "TYPE")
+}
+
+// DnsQClass is a dns query class.
+type DnsQClass uint16
+
+const (
+	DnsClassInternet DnsQClass = 1
+	DnsClassCSNet    DnsQClass = 2
+	DnsClassChaos    DnsQClass = 3
+	DnsClassHesiod   DnsQClass = 4
+	DnsClassNone     DnsQClass = 254
+	DnsClassAny      DnsQClass = 255
+)
+
+var dnsQClassNames = map[DnsQClass]string{
+	DnsClassInternet: "C_INTERNET",
+	DnsClassCSNet:    "C_CSNET",
+	DnsClassChaos:    "C_CHAOS",
+	DnsClassHesiod:   "C_HESIOD",
+	DnsClassNone:     "C_NONE",
+	DnsClassAny:      "C_ANY",
+}
+
+// IANA mnemonics for the classes, zeek logs the C_ names above.
+var dnsQClassMnemonics = map[string]DnsQClass{
+	"IN":   DnsClassInternet,
+	"CS":   DnsClassCSNet,
+	"CH":   DnsClassChaos,
+	"HS":   DnsClassHesiod,
+	"NONE": DnsClassNone,
+	"ANY":  DnsClassAny,
+}
+
+// String returns the name zeek logs for the class (ie: C_INTERNET) or qclass-N for classes not
+// in the registry.
+func (c DnsQClass) String() string {
+	if name, ok := dnsQClassNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("qclass-%d", c)
+}
+
+// DnsQClassByName returns the class for the given name as logged in qclass_name.  The IANA
+// mnemonics (ie: IN, CH) and CLASSN are understood as well.
+func DnsQClassByName(givenName string) (DnsQClass, bool) {
+	if class, ok := dnsQClassMnemonics[strings.ToUpper(givenName)]; ok {
+		return class, true
+	}
+	return dnsCodeByName(givenName, dnsQClassNames, "qclass-", "CLASS")
+}
+
+// DnsRCode is a dns response code.
+type DnsRCode uint16
+
+const (
+	DnsRCodeNoError   DnsRCode = 0
+	DnsRCodeFormErr   DnsRCode = 1
+	DnsRCodeServFail  DnsRCode = 2
+	DnsRCodeNXDomain  DnsRCode = 3
+	DnsRCodeNotImp    DnsRCode = 4
+	DnsRCodeRefused   DnsRCode = 5
+	DnsRCodeYXDomain  DnsRCode = 6
+	DnsRCodeYXRRSet   DnsRCode = 7
+	DnsRCodeNXRRSet   DnsRCode = 8
+	DnsRCodeNotAuth   DnsRCode = 9
+	DnsRCodeNotZone   DnsRCode = 10
+	DnsRCodeDSOTypeNI DnsRCode = 11
+	DnsRCodeBadVers   DnsRCode = 16
+	DnsRCodeBadKey    DnsRCode = 17
+	DnsRCodeBadTime   DnsRCode = 18
+	DnsRCodeBadMode   DnsRCode = 19
+	DnsRCodeBadName   DnsRCode = 20
+	DnsRCodeBadAlg    DnsRCode = 21
+	DnsRCodeBadTrunc  DnsRCode = 22
+	DnsRCodeBadCookie DnsRCode = 23
+)
+
+var dnsRCodeNames = map[DnsRCode]string{
+	DnsRCodeNoError:   "NOERROR",
+	DnsRCodeFormErr:   "FORMERR",
+	DnsRCodeServFail:  "SERVFAIL",
+	DnsRCodeNXDomain:  "NXDOMAIN",
+	DnsRCodeNotImp:    "NOTIMP",
+	DnsRCodeRefused:   "REFUSED",
+	DnsRCodeYXDomain:  "YXDOMAIN",
+	DnsRCodeYXRRSet:   "YXRRSET",
+	DnsRCodeNXRRSet:   "NXRRSET",
+	DnsRCodeNotAuth:   "NOTAUTH",
+	DnsRCodeNotZone:   "NOTZONE",
+	DnsRCodeDSOTypeNI: "DSOTYPENI",
+	DnsRCodeBadVers:   "BADVERS",
+	DnsRCodeBadKey:    "BADKEY",
+	DnsRCodeBadTime:   "BADTIME",
+	DnsRCodeBadMode:   "BADMODE",
+	DnsRCodeBadName:   "BADNAME",
+	DnsRCodeBadAlg:    "BADALG",
+	DnsRCodeBadTrunc:  "BADTRUNC",
+	DnsRCodeBadCookie: "BADCOOKIE",
+}
+
+// String returns the name zeek logs for the response code (ie: NXDOMAIN) or rcode-N for codes
+// not in the registry.
+func (r DnsRCode) String() string {
+	if name, ok := dnsRCodeNames[r]; ok {
+		return name
+	}
+	return fmt.Sprintf("rcode-%d", r)
+}
+
+// DnsRCodeByName returns the response code for the given name as logged in rcode_name, matched
+// ignoring case.  BADSIG (which shares 16 with BADVERS) and RCODEN are understood as well.
+func DnsRCodeByName(givenName string) (DnsRCode, bool) {
+	if strings.EqualFold(givenName, "BADSIG") {
+		return DnsRCodeBadVers, true
+	}
+	return dnsCodeByName(givenName, dnsRCodeNames, "rcode-", "RCODE")
+}
+
+// IsNoError tells if the response code is NOERROR.
+func (r DnsRCode) IsNoError() bool {
+	return r == DnsRCodeNoError
+}
+
+// IsNXDomain tells if the response code is NXDOMAIN (the name does not exist).
+func (r DnsRCode) IsNXDomain() bool {
+	return r == DnsRCodeNXDomain
+}
+
+// IsServFail tells if the response code is SERVFAIL.
+func (r DnsRCode) IsServFail() bool {
+	return r == DnsRCodeServFail
+}
+
+// IsRefused tells if the response code is REFUSED.
+func (r DnsRCode) IsRefused() bool {
+	return r == DnsRCodeRefused
+}
+
+// dnsCodeByName looks the given name up in the given table ignoring case, falling back to the
+// numeric forms with the given prefixes (ie: query-65280 or TYPE65280).
+func dnsCodeByName[T ~uint16](givenName string, givenNames map[T]string, givenPrefixes ...string) (T, bool) {
+	for code, name := range givenNames {
+		if strings.EqualFold(name, givenName) {
+			return code, true
+		}
+	}
+	for _, thisPrefix := range givenPrefixes {
+		if len(givenName) > len(thisPrefix) && strings.EqualFold(givenName[:len(thisPrefix)], thisPrefix) {
+			code, err := strconv.ParseUint(givenName[len(thisPrefix):], 10, 16)
+			if err == nil {
+				return T(code), true
+			}
+		}
+	}
+	return 0, false
+}
+
+// optDnsCode is a convenience function for parsers that will convert the given value to an
+// Opt of one of the dns code types, leaving it unset if it matches the unset char given.
+func optDnsCode[T ~uint16](givenValue string, givenUnset string) (Opt[T], error) {
+	if givenValue == givenUnset {
+		return Opt[T]{}, nil
+	}
+	parsed, err := strconv.ParseUint(givenValue, 10, 16)
+	if err != nil {
+		return Opt[T]{}, err
+	}
+	return Some(T(parsed)), nil
+}
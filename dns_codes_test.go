@@ -0,0 +1,71 @@
+package zeekparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDnsCodeNames(t *testing.T) {
+	assert.Equal(t, "AAAA", DnsTypeAAAA.String())
+	assert.Equal(t, "*", DnsTypeANY.String())
+	assert.Equal(t, "query-65280", DnsQType(65280).String())
+	assert.Equal(t, "C_INTERNET", DnsClassInternet.String())
+	assert.Equal(t, "qclass-9", DnsQClass(9).String())
+	assert.Equal(t, "NXDOMAIN", DnsRCodeNXDomain.String())
+	assert.Equal(t, "rcode-24", DnsRCode(24).String())
+
+	for _, thisCase := range []struct {
+		name  string
+		qtype DnsQType
+	}{{"https", DnsTypeHTTPS}, {"*", DnsTypeANY}, {"ANY", DnsTypeANY}, {"query-65280", 65280}, {"TYPE65280", 65280}} {
+		qtype, ok := DnsQTypeByName(thisCase.name)
+		assert.True(t, ok, thisCase.name)
+		assert.Equal(t, thisCase.qtype, qtype, thisCase.name)
+	}
+	_, ok := DnsQTypeByName("NOTATYPE")
+	assert.False(t, ok)
+	_, ok = DnsQTypeByName("query-99999")
+	assert.False(t, ok)
+
+	class, ok := DnsQClassByName("CH")
+	assert.True(t, ok)
+	assert.Equal(t, DnsClassChaos, class)
+
+	rcode, ok := DnsRCodeByName("BADSIG")
+	assert.True(t, ok)
+	assert.Equal(t, DnsRCodeBadVers, rcode)
+	assert.True(t, DnsRCodeServFail.IsServFail())
+	assert.False(t, DnsRCodeNoError.IsNXDomain())
+}
+
+func TestParseDNSLogCodes(t *testing.T) {
+	// only one of the numeric or name columns was logged for each code
+	allDns, err := ParseDNSLog("test_input/simple_dns_codes.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, len(allDns))
+
+	https := allDns[0]
+	assert.Equal(t, Some(DnsTypeHTTPS), https.QType)
+	assert.Equal(t, "HTTPS", https.QTypeName)
+	assert.Equal(t, Some(DnsClassInternet), https.QClass)
+	assert.Equal(t, Some(DnsRCodeNoError), https.RCode)
+
+	assert.True(t, allDns[1].IsNXDomain())
+	assert.True(t, allDns[2].IsServFail())
+	assert.Equal(t, "query-65280", allDns[3].QTypeName)
+	assert.Equal(t, Some(DnsRCode(24)), allDns[3].RCode)
+
+	unset := allDns[4]
+	assert.False(t, unset.QType.IsSet())
+	assert.False(t, unset.RCode.IsSet())
+	assert.Equal(t, "", unset.RCodeName)
+	assert.False(t, unset.IsNXDomain())
+
+	// both columns logged
+	allFull, err := ParseDNSLog("test_input/simple_dns_full.log")
+	assert.NoError(t, err)
+	nxdomain := FilterDnsByRCode(allFull, DnsRCodeNXDomain)
+	assert.Equal(t, 1, len(nxdomain))
+	assert.Equal(t, "nope.example.com", nxdomain[0].Query)
+	assert.Equal(t, Some(DnsTypeMX), allFull[3].QType)
+}
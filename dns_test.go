@@ -31,3 +31,37 @@ func TestParseDNSLog(t *testing.T) {
 	_, err := ParseDNSLog("test_input/simple_dns.log.gz")
 	assert.NoError(t, err)
 }
+
+func TestParseDNSLogFullSchema(t *testing.T) {
+	allDns, err := ParseDNSLog("test_input/simple_dns_full.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 6, len(allDns))
+
+	cname := allDns[0]
+	assert.Equal(t, "WWW.Example.com", cname.OriginalQuery)
+	assert.Equal(t, map[string]string{"icann_tld": "com"}, cname.Extra)
+	assert.Equal(t, []DnsAnswer{
+		{Value: "example.com.cdn.example.net", Type: DnsAnswerCNAME, TTL: Some(300.0)},
+		{Value: "93.184.216.34", Type: DnsAnswerA, TTL: Some(60.0)},
+	}, cname.ClassifiedAnswers())
+	assert.Equal(t, []string{"93.184.216.34"}, cname.AnswersOfType(DnsAnswerA))
+
+	assert.Equal(t, []string{"2606:2800:220:1:248:1893:25c8:1946"}, allDns[1].AnswersOfType(DnsAnswerAAAA))
+	assert.Equal(t, []string{"edge.example.net"}, allDns[2].AnswersOfType(DnsAnswerPTR))
+
+	mx := allDns[3]
+	assert.Equal(t, DnsAnswerOther, mx.ClassifiedAnswers()[0].Type)
+	assert.Equal(t, []string{"mail.example.com"}, mx.Addl)
+
+	// unset vectors are nil rather than holding a placeholder
+	nx := allDns[4]
+	assert.Nil(t, nx.Answers)
+	assert.Nil(t, nx.TTLs)
+	assert.Nil(t, nx.ClassifiedAnswers())
+	assert.Equal(t, []string{"ns1.example.com", "ns2.example.com"}, nx.Auth)
+
+	txt := allDns[5]
+	assert.Equal(t, DnsAnswerOther, txt.ClassifiedAnswers()[0].Type)
+	assert.Nil(t, txt.Auth)
+	assert.Equal(t, "", txt.Extra["icann_tld"])
+}
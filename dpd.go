@@ -0,0 +1,131 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"time"
+)
+
+// dpd log format described in https://docs.zeek.org/en/master/scripts/base/frameworks/dpd/main.zeek.html#type-DPD::Info
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// DpdEntry is a fully parsed dpd.log line.
+type DpdEntry struct {
+	TS      time.Time // TS:time - timestamp
+	Uid     string    // Uid:string - unique id
+	IdOrigH string    // id_orig_h:addr - senders address
+	IdOrigP int       // id_orig_p:addr - senders port
+	IdRespH string    // id_resp_h:port - responders address
+	IdRespP int       // id_resp_p:port - responders port
+	// ---------
+	Proto         Proto  // proto:enum - transport protocol for the violation
+	Analyzer      string // analyzer:string - the analyzer that generated the violation
+	FailureReason string // failure_reason:string - the textual reason for the analysis failure
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (d *DpdEntry) Print() {
+	fmt.Printf("(%s) client {%s:%d} talks to {%s:%d}:\n",
+		d.TS.String(), d.IdOrigH, d.IdOrigP, d.IdRespH, d.IdRespP)
+	fmt.Printf("\t%s %s: %s\n", d.Proto, d.Analyzer, d.FailureReason)
+}
+
+func (d *DpdEntry) ShortPrint() {
+	fmt.Printf("[%s] %s -> %s:%d %s %s\n", d.TS, d.IdOrigH, d.IdRespH, d.IdRespP, d.Analyzer, d.FailureReason)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToDpdStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (dpdEntry DpdEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			dpdEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "uid":
+			dpdEntry.Uid = thisField.value
+		case "id.orig_h":
+			dpdEntry.IdOrigH = thisField.value
+		case "id.orig_p":
+			dpdEntry.IdOrigP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id.resp_h":
+			dpdEntry.IdRespH = thisField.value
+		case "id.resp_p":
+			dpdEntry.IdRespP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "proto":
+			dpdEntry.Proto = ParseProto(thisField.value)
+		case "analyzer":
+			dpdEntry.Analyzer = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "failure_reason":
+			dpdEntry.FailureReason = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseDpdLog will parse through the given single dpd log (passed as a filename string)
+func ParseDpdLog(givenFilename string) (parsedResults []DpdEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes DpdEntry
+		thisRes, err = thisLogEntryToDpdStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseDpdRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseDpdRecurse(givenDirectory string) (allResults []DpdEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "dpd") {
+		thisResult, parseErr := ParseDpdLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllDpdForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed DpdEntry objects
+func GetAllDpdForDay(givenDay string, givenZeekDir ...string) (allRes []DpdEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseDpdRecurse(zeekDir + givenDay + "/")
+	return
+}
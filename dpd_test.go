@@ -0,0 +1,28 @@
+package zeekparse
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThisLogEntryToDpdStruct(t *testing.T) {
+	log.SetFormatter(&log.TextFormatter{ForceColors: true})
+	log.SetLevel(log.InfoLevel)
+
+	// compressed case
+	compressedResults, header, compErr := parseZeekLog("test_input/simple_dpd.log.gz")
+	for _, thisResult := range compressedResults {
+		_, parseErr := thisLogEntryToDpdStruct(thisResult, header)
+		assert.NoError(t, parseErr)
+	}
+	assert.NoError(t, compErr)
+
+	allDpd, err := ParseDpdLog("test_input/simple_dpd.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(allDpd))
+	assert.Equal(t, TCP, allDpd[0].Proto)
+	assert.Equal(t, "SSL", allDpd[0].Analyzer)
+	assert.Equal(t, UDP, allDpd[1].Proto)
+	assert.Equal(t, "excessive AN records", allDpd[1].FailureReason)
+}
@@ -7,11 +7,17 @@ package main
 import (
 	"fmt"
 	"github.com/jakubd/zeekparse"
-	"strings"
 )
 
 func main() {
 
+	// set up the local networks here to match your network
+	site, err := zeekparse.NewSite("192.168.1.0/24")
+	if err != nil {
+		panic(err)
+	}
+	bytesThreshold := 2500
+
 	// look at the last 3 months
 	for _, thisDate := range zeekparse.LastXMonths(3) {
 
@@ -21,28 +27,21 @@ func main() {
 			panic(err)
 		}
 
-		// set up vars here to match your network
-		localSubnet := "192.168.1."
-		bytesThreshold := 2500
-
 		for _, thisConn := range allConn {
-
-			// if the OriginatingHost is on the local subnet
-			if strings.HasPrefix(thisConn.IdOrigH, localSubnet) &&
-				// and the destination is not on the local subnet
-				!strings.HasPrefix(thisConn.IdRespH, localSubnet) &&
+			// if the OriginatingHost is on the local subnet and the destination is not
+			if site.Classify(&thisConn) == zeekparse.DirectionOutbound &&
 				// and the destination address is not a broadcast or multicast address
 				!zeekparse.IsMulticastOrBroadcastAddress(thisConn.IdRespH) &&
 				// If the protocol is either TCP/UDP
-				thisConn.Proto != zeekparse.NONE &&
+				(thisConn.Proto == zeekparse.TCP || thisConn.Proto == zeekparse.UDP) &&
 				// and the bytesThreshold is larger than what we set
-				thisConn.OrigBytes > bytesThreshold {
+				thisConn.OrigBytes.Value() > bytesThreshold {
 				// and is an upload where sentBytes > receivedBytes
-				if thisConn.OrigBytes > thisConn.RespBytes {
+				if thisConn.OrigBytes.Value() > thisConn.RespBytes.Value() {
 
 					// then print the info to screen
 					fmt.Printf("{%s} client [%s:%d] uploaded %d bytes to [%s:%d]\n", thisConn.TS.String(), thisConn.IdOrigH, thisConn.IdOrigP,
-						thisConn.OrigBytes, thisConn.IdRespH, thisConn.IdRespP)
+						thisConn.OrigBytes.Value(), thisConn.IdRespH, thisConn.IdRespP)
 				}
 			}
 		}
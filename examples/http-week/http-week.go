@@ -2,7 +2,6 @@ package main
 
 import (
 	"github.com/jakubd/zeekparse"
-	"strings"
 )
 
 // http-week.go - show the last week of non-local, non-reverse DNS resolutions and their replies exclude all domains
@@ -10,7 +9,10 @@ import (
 
 func main() {
 
-	localSubnet := "192.168.1."
+	site, err := zeekparse.NewSite("192.168.1.0/24")
+	if err != nil {
+		panic(err)
+	}
 
 	// lets look at last 12 days
 	for _, thisDay := range zeekparse.LastXDays(12) {
@@ -23,7 +25,7 @@ func main() {
 
 		// iterate all the days http requests
 		for _, thisLookup := range thisDayHttp {
-			if !strings.HasPrefix(thisLookup.IdRespH, localSubnet) {
+			if !site.IsLocalStr(thisLookup.IdRespH) {
 				thisLookup.ShortPrint()
 			}
 		}
@@ -17,6 +17,7 @@ func Contains(a []string, x string) bool {
 
 func main() {
 	var seenCerts []string
+	var allCerts []zeekparse.X509Entry
 	// lets look at last 12 days
 	for _, thisDay := range zeekparse.LastXDays(12) {
 
@@ -26,6 +27,8 @@ func main() {
 			panic(err)
 		}
 
+		allCerts = append(allCerts, thisDayCerts...)
+
 		// iterate all the days x509 certs
 		for _, thisCert := range thisDayCerts {
 			if !Contains(seenCerts, thisCert.CertSubject) {
@@ -37,4 +40,10 @@ func main() {
 	for _, thisCert := range seenCerts {
 		fmt.Println(thisCert)
 	}
+
+	// then any certs that need attention
+	fmt.Println()
+	for _, thisResult := range zeekparse.CheckCerts(allCerts, zeekparse.SeverityMedium) {
+		thisResult.Print()
+	}
 }
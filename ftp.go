@@ -0,0 +1,170 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"time"
+)
+
+// ftp log format described in https://docs.zeek.org/en/master/scripts/base/protocols/ftp/info.zeek.html#type-FTP::Info
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// FtpEntry is a fully parsed ftp.log line.
+type FtpEntry struct {
+	TS      time.Time // TS:time - timestamp
+	Uid     string    // Uid:string - unique id
+	IdOrigH string    // id_orig_h:addr - senders address
+	IdOrigP int       // id_orig_p:addr - senders port
+	IdRespH string    // id_resp_h:port - responders address
+	IdRespP int       // id_resp_p:port - responders port
+	// ---------
+	User               string   // user:string - user name for the current FTP session
+	Password           string   // password:string - password for the current FTP session if captured
+	Command            string   // command:string - command given by the client
+	Arg                string   // arg:string - argument for the command if one is given
+	MimeType           string   // mime_type:string - sniffed mime type of file
+	FileSize           Opt[int] // file_size:count - size of the file if the command indicates a file transfer
+	ReplyCode          Opt[int] // reply_code:count - reply code from the server in response to the command
+	ReplyMsg           string   // reply_msg:string - reply message from the server in response to the command
+	DataChannelPassive bool     // data_channel.passive:bool - whether PASV mode is toggled for the data channel
+	DataChannelOrigH   string   // data_channel.orig_h:addr - host that will be initiating the data connection
+	DataChannelRespH   string   // data_channel.resp_h:addr - host that will be accepting the data connection
+	DataChannelRespP   Opt[int] // data_channel.resp_p:port - port at which the acceptor is listening for the data connection
+	Fuid               string   // fuid:string - file unique id
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (f *FtpEntry) Print() {
+	fmt.Printf("(%s) client {%s:%d} talks to {%s:%d}:\n",
+		f.TS.String(), f.IdOrigH, f.IdOrigP, f.IdRespH, f.IdRespP)
+	fmt.Printf("\t%s: %s %s -> %s %s\n", f.User, f.Command, f.Arg, f.ReplyCode, f.ReplyMsg)
+}
+
+func (f *FtpEntry) ShortPrint() {
+	fmt.Printf("[%s] %s %s %s %s\n", f.TS, f.IdOrigH, f.User, f.Command, f.Arg)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToFtpStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (ftpEntry FtpEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			ftpEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "uid":
+			ftpEntry.Uid = thisField.value
+		case "id.orig_h":
+			ftpEntry.IdOrigH = thisField.value
+		case "id.orig_p":
+			ftpEntry.IdOrigP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id.resp_h":
+			ftpEntry.IdRespH = thisField.value
+		case "id.resp_p":
+			ftpEntry.IdRespP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "user":
+			ftpEntry.User = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "password":
+			ftpEntry.Password = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "command":
+			ftpEntry.Command = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "arg":
+			ftpEntry.Arg = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "mime_type":
+			ftpEntry.MimeType = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "file_size":
+			ftpEntry.FileSize, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "reply_code":
+			ftpEntry.ReplyCode, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "reply_msg":
+			ftpEntry.ReplyMsg = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "data_channel.passive":
+			ftpEntry.DataChannelPassive = thisField.value == "T"
+		case "data_channel.orig_h":
+			ftpEntry.DataChannelOrigH = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "data_channel.resp_h":
+			ftpEntry.DataChannelRespH = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "data_channel.resp_p":
+			ftpEntry.DataChannelRespP, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "fuid":
+			ftpEntry.Fuid = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseFtpLog will parse through the given single ftp log (passed as a filename string)
+func ParseFtpLog(givenFilename string) (parsedResults []FtpEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes FtpEntry
+		thisRes, err = thisLogEntryToFtpStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseFtpRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseFtpRecurse(givenDirectory string) (allResults []FtpEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "ftp") {
+		thisResult, parseErr := ParseFtpLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllFtpForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed FtpEntry objects
+func GetAllFtpForDay(givenDay string, givenZeekDir ...string) (allRes []FtpEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseFtpRecurse(zeekDir + givenDay + "/")
+	return
+}
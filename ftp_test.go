@@ -0,0 +1,31 @@
+package zeekparse
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThisLogEntryToFtpStruct(t *testing.T) {
+	log.SetFormatter(&log.TextFormatter{ForceColors: true})
+	log.SetLevel(log.InfoLevel)
+
+	// compressed case
+	compressedResults, header, compErr := parseZeekLog("test_input/simple_ftp.log.gz")
+	for _, thisResult := range compressedResults {
+		_, ftpErr := thisLogEntryToFtpStruct(thisResult, header)
+		assert.NoError(t, ftpErr)
+	}
+	assert.NoError(t, compErr)
+}
+
+func TestParseFtpLog(t *testing.T) {
+	allFtp, err := ParseFtpLog("test_input/simple_ftp.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(allFtp))
+	assert.Equal(t, Some(1204), allFtp[0].FileSize)
+	assert.True(t, allFtp[0].DataChannelPassive)
+	assert.Equal(t, Some(50122), allFtp[0].DataChannelRespP)
+	assert.False(t, allFtp[1].FileSize.IsSet())
+	assert.False(t, allFtp[2].DataChannelRespP.IsSet())
+}
@@ -1,8 +1,15 @@
 module github.com/jakubd/zeekparse
 
-go 1.13
+go 1.18
 
 require (
 	github.com/sirupsen/logrus v1.6.0
 	github.com/stretchr/testify v1.5.1
 )
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.0.0-20190422165155-953cdadca894 // indirect
+	gopkg.in/yaml.v2 v2.2.2 // indirect
+)
@@ -0,0 +1,203 @@
+/*
+Summarises sensor health per day and per node out of capture_loss.log, stats.log,
+reporter.log and packet_filter.log.  Useful to tell whether the other logs for a day
+are complete before trusting results derived from them.
+*/
+
+package zeekparse
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// NodeHealth is the health of a single zeek node (or peer) on a single day.
+type NodeHealth struct {
+	Node           string
+	Gaps           int     // total missed ACKs from capture_loss.log
+	Acks           int     // total ACKs seen from capture_loss.log
+	MaxPercentLost float64 // worst capture_loss.log measurement interval
+	PktsProc       int     // total packets processed from stats.log
+	PktsDropped    int     // total packets dropped from stats.log
+	PktsLink       int     // total packets seen on the link from stats.log
+	FilterFailures int     // packet filters that failed to install
+}
+
+// PercentLost returns the capture loss over the whole day the same way zeek calculates it
+// for a single interval (gaps / acks).  Returns 0 if no ACKs were seen.
+func (n *NodeHealth) PercentLost() float64 {
+	if n.Acks <= 0 {
+		return 0
+	}
+	return float64(n.Gaps) / float64(n.Acks) * 100
+}
+
+// PercentDropped returns the percentage of packets on the link dropped by the sensor.  Returns 0
+// if link counts were not available.
+func (n *NodeHealth) PercentDropped() float64 {
+	if n.PktsLink <= 0 {
+		return 0
+	}
+	return float64(n.PktsDropped) / float64(n.PktsLink) * 100
+}
+
+// DayHealth is the health of all nodes on a single day.  reporter.log doesn't say which node a
+// message came from so reporter counts are kept for the whole day.
+type DayHealth struct {
+	Day              string // YYYY-MM-DD
+	ReporterErrors   int
+	ReporterWarnings int
+	Nodes            map[string]*NodeHealth
+}
+
+// PercentLost returns the capture loss across all nodes for the day.
+func (d *DayHealth) PercentLost() float64 {
+	var total NodeHealth
+	for _, thisNode := range d.Nodes {
+		total.Gaps += thisNode.Gaps
+		total.Acks += thisNode.Acks
+	}
+	return total.PercentLost()
+}
+
+// PercentDropped returns the percentage of packets dropped across all nodes for the day.  Nodes
+// without link counts are left out as their drops can't be compared to anything.
+func (d *DayHealth) PercentDropped() float64 {
+	var total NodeHealth
+	for _, thisNode := range d.Nodes {
+		if thisNode.PktsLink <= 0 {
+			continue
+		}
+		total.PktsDropped += thisNode.PktsDropped
+		total.PktsLink += thisNode.PktsLink
+	}
+	return total.PercentDropped()
+}
+
+// IsHealthy tells if the day had no reporter errors, no failed packet filters and capture loss
+// and packet drops at or below the given percentage.
+func (d *DayHealth) IsHealthy(givenMaxPercent float64) bool {
+	if d.ReporterErrors > 0 {
+		return false
+	}
+	for _, thisNode := range d.Nodes {
+		if thisNode.FilterFailures > 0 {
+			return false
+		}
+	}
+	return d.PercentLost() <= givenMaxPercent && d.PercentDropped() <= givenMaxPercent
+}
+
+func (d *DayHealth) Print() {
+	fmt.Printf("%s loss:%.3f%% dropped:%.3f%% reporter errors:%d warnings:%d\n",
+		d.Day, d.PercentLost(), d.PercentDropped(), d.ReporterErrors, d.ReporterWarnings)
+	var nodeNames []string
+	for thisName := range d.Nodes {
+		nodeNames = append(nodeNames, thisName)
+	}
+	sort.Strings(nodeNames)
+	for _, thisName := range nodeNames {
+		thisNode := d.Nodes[thisName]
+		fmt.Printf("\t%s loss:%.3f%% (worst %.3f%%) dropped:%.3f%% filter failures:%d\n",
+			thisNode.Node, thisNode.PercentLost(), thisNode.MaxPercentLost, thisNode.PercentDropped(), thisNode.FilterFailures)
+	}
+}
+
+// SensorHealth is a health summary keyed by day (YYYY-MM-DD).
+type SensorHealth map[string]*DayHealth
+
+// ForTime returns the health of the day the given time falls on, or nil if there is no
+// health information for that day.  Use this to annotate results derived from other logs.
+func (s SensorHealth) ForTime(givenTS time.Time) *DayHealth {
+	return s[TimeToDateStr(givenTS)]
+}
+
+// get the given day and node creating them if needed.
+func (s SensorHealth) getNode(givenTS time.Time, givenNode string) *NodeHealth {
+	d := s.getDay(givenTS)
+	n, ok := d.Nodes[givenNode]
+	if !ok {
+		n = &NodeHealth{Node: givenNode}
+		d.Nodes[givenNode] = n
+	}
+	return n
+}
+
+// get the given day creating it if needed.
+func (s SensorHealth) getDay(givenTS time.Time) *DayHealth {
+	day := TimeToDateStr(givenTS)
+	d, ok := s[day]
+	if !ok {
+		d = &DayHealth{Day: day, Nodes: make(map[string]*NodeHealth)}
+		s[day] = d
+	}
+	return d
+}
+
+// BuildSensorHealth summarises the given health log entries per day and per node.
+func BuildSensorHealth(givenLoss []CaptureLossEntry, givenStats []StatsEntry,
+	givenReporter []ReporterEntry, givenFilters []PacketFilterEntry) SensorHealth {
+
+	health := make(SensorHealth)
+
+	for _, thisLoss := range givenLoss {
+		n := health.getNode(thisLoss.TS, thisLoss.Peer)
+		n.Gaps += thisLoss.Gaps.Value()
+		n.Acks += thisLoss.Acks.Value()
+		if thisLoss.PercentLost.Value() > n.MaxPercentLost {
+			n.MaxPercentLost = thisLoss.PercentLost.Value()
+		}
+	}
+
+	for _, thisStats := range givenStats {
+		n := health.getNode(thisStats.TS, thisStats.Peer)
+		n.PktsProc += thisStats.PktsProc.Value()
+		n.PktsDropped += thisStats.PktsDropped.Value()
+		n.PktsLink += thisStats.PktsLink.Value()
+	}
+
+	for _, thisReport := range givenReporter {
+		d := health.getDay(thisReport.TS)
+		if thisReport.IsError() {
+			d.ReporterErrors++
+		} else if thisReport.IsWarning() {
+			d.ReporterWarnings++
+		}
+	}
+
+	for _, thisFilter := range givenFilters {
+		n := health.getNode(thisFilter.TS, thisFilter.Node)
+		if !thisFilter.Success {
+			n.FilterFailures++
+		}
+	}
+
+	return health
+}
+
+// GetSensorHealthForDay parses the health logs on the given day from the default zeek directory
+// and returns the summary for that day.
+func GetSensorHealthForDay(givenDay string, givenZeekDir ...string) (dayHealth *DayHealth, err error) {
+	allLoss, err := GetAllCaptureLossForDay(givenDay, givenZeekDir...)
+	if err != nil {
+		return
+	}
+	allStats, err := GetAllStatsForDay(givenDay, givenZeekDir...)
+	if err != nil {
+		return
+	}
+	allReporter, err := GetAllReporterForDay(givenDay, givenZeekDir...)
+	if err != nil {
+		return
+	}
+	allFilters, err := GetAllPacketFilterForDay(givenDay, givenZeekDir...)
+	if err != nil {
+		return
+	}
+	dayHealth = BuildSensorHealth(allLoss, allStats, allReporter, allFilters)[givenDay]
+	if dayHealth == nil {
+		dayHealth = &DayHealth{Day: givenDay, Nodes: make(map[string]*NodeHealth)}
+	}
+	return
+}
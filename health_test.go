@@ -0,0 +1,54 @@
+package zeekparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestBuildSensorHealth(t *testing.T) {
+	allLoss, err := ParseCaptureLossLog("test_input/simple_capture_loss.log")
+	assert.NoError(t, err)
+	allStats, err := ParseStatsLog("test_input/simple_stats.log")
+	assert.NoError(t, err)
+	allReporter, err := ParseReporterLog("test_input/simple_reporter.log")
+	assert.NoError(t, err)
+	allFilters, err := ParsePacketFilterLog("test_input/simple_packet_filter.log")
+	assert.NoError(t, err)
+
+	health := BuildSensorHealth(allLoss, allStats, allReporter, allFilters)
+	day := health.ForTime(time.Unix(1621138500, 0))
+	assert.NotNil(t, day)
+	assert.Equal(t, 2, len(day.Nodes))
+	assert.Equal(t, 1, day.ReporterErrors)
+	assert.Equal(t, 1, day.ReporterWarnings)
+
+	zeekNode := day.Nodes["zeek"]
+	assert.InDelta(t, 1.0, zeekNode.PercentLost(), 0.0001)
+	assert.Equal(t, 3.0, zeekNode.MaxPercentLost)
+	assert.InDelta(t, 0.4975, zeekNode.PercentDropped(), 0.0001)
+
+	// worker-2 has no link counts so drops can't be calculated
+	assert.Equal(t, 0.0, day.Nodes["worker-2"].PercentDropped())
+
+	assert.False(t, day.IsHealthy(5))
+	day.ReporterErrors = 0
+	assert.True(t, day.IsHealthy(5))
+	assert.False(t, day.IsHealthy(0.5))
+
+	assert.Nil(t, health.ForTime(time.Unix(0, 0)))
+}
+
+func TestDayHealthPercentDropped(t *testing.T) {
+	day := DayHealth{Nodes: map[string]*NodeHealth{
+		"worker-1": {Node: "worker-1", PktsDropped: 10, PktsLink: 1000},
+		"worker-2": {Node: "worker-2", PktsDropped: 5000},
+	}}
+
+	// worker-2 has no link counts so its drops are left out rather than pushing the day over 100%
+	assert.InDelta(t, 1.0, day.PercentDropped(), 0.0001)
+	assert.True(t, day.IsHealthy(1))
+
+	day.Nodes["worker-2"].PktsLink = 9000
+	assert.InDelta(t, 50.1, day.PercentDropped(), 0.0001)
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	log "github.com/sirupsen/logrus"
+	"net"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -23,6 +25,7 @@ type HttpEntry struct {
 	IdRespH string    		// id_resp_h:port - responders address
 	IdRespP int       		// id_resp_p:port - responders port
 	// -----
+	TransDepth Opt[int]			// trans_depth:count - pipelined depth into the connection of this request/response transaction
 	Method string			// method:string - Verb of HTTP request
 	Host string				// host:string - Host header value
 	Uri string				// uri:string - URI of the request
@@ -30,11 +33,22 @@ type HttpEntry struct {
 	Version string			// version:string - HTTP version used
 	UserAgent string		// user_agent:string - User agent of the request
 	Origin string			// origin:string - Origin header value
-	ReqLen int				// request_body_len:count - Request body length
-	RespLen int				// response_body_len:count - Response body length
-	StatusCode int			// status_code: count - status code (if any) returned by server
+	ReqLen Opt[int]				// request_body_len:count - Request body length
+	RespLen Opt[int]				// response_body_len:count - Response body length
+	StatusCode Opt[int]			// status_code: count - status code (if any) returned by server
 	StatusMsg string		// status_msg:string - status message (if any) returned by server
-	MimeTypes []string		// orig_mime_types:vector[string] - mime types in resp (can be more than one)
+	InfoCode Opt[int]			// info_code:count - last 1xx informational reply code returned by the server
+	InfoMsg string			// info_msg:string - last 1xx informational reply message returned by the server
+	Tags []string			// tags:set[enum] - indicators of various attributes discovered and related to a particular request/response pair
+	Username string			// username:string - username if basic-auth is performed for the request
+	Password string			// password:string - password if basic-auth is performed for the request (only logged if HTTP::default_capture_password is set)
+	Proxied []string		// proxied:set[string] - all of the headers that may indicate if the request was proxied
+	OrigFuids []string		// orig_fuids:vector[string] - ordered file unique ids sent by the client
+	OrigFilenames []string	// orig_filenames:vector[string] - ordered filenames sent by the client
+	MimeTypes []string		// orig_mime_types:vector[string] - ordered mime types of the files sent by the client
+	RespFuids []string		// resp_fuids:vector[string] - ordered file unique ids sent by the server
+	RespFilenames []string	// resp_filenames:vector[string] - ordered filenames sent by the server
+	RespMimeTypes []string	// resp_mime_types:vector[string] - ordered mime types of the files sent by the server
 }
 
 // ------------------------------
@@ -44,24 +58,136 @@ type HttpEntry struct {
 func (thisEntry *HttpEntry) Print() {
 	fmt.Printf("(%s) client {%s:%d} asks server {%s:%d}:\n",
 		thisEntry.TS.String(), thisEntry.IdOrigH, thisEntry.IdOrigP, thisEntry.IdRespH, thisEntry.IdRespP)
+	fmt.Printf("HTTP %s %s %s\n", thisEntry.Version, thisEntry.Method, thisEntry.URLString())
+}
+
+// ShortPrint will just print the requested URL as a one liner
+func (thisEntry *HttpEntry) ShortPrint() {
+	fmt.Printf("[%s] %s -> %s\n", thisEntry.TS, thisEntry.IdOrigH, thisEntry.URLString())
+}
+
+// ------------------------------
+// ----    Entry Helpers   ------
+// ------------------------------
+
+// URL returns the full requested URL.  Absolute-form URIs sent to proxies are used as given,
+// CONNECT requests give a URL with only the Host (the host and port tunnelled to) set and
+// otherwise the host comes from the Host header (or the responder address if there was none).
+// The port is only included when it isn't the default port for the scheme (80 for http, 443
+// for https).
+func (thisEntry *HttpEntry) URL() (*url.URL, error) {
+	if thisEntry.isConnect() {
+		return &url.URL{Host: thisEntry.Uri}, nil
+	}
+	lowerUri := strings.ToLower(thisEntry.Uri)
+	if strings.HasPrefix(lowerUri, "http://") || strings.HasPrefix(lowerUri, "https://") {
+		parsed, err := url.Parse(thisEntry.Uri)
+		if err != nil {
+			return nil, err
+		}
+		if parsed.Port() == defaultPortForScheme(parsed.Scheme) {
+			parsed.Host = bracketIPv6(parsed.Hostname())
+		}
+		return parsed, nil
+	}
 
-	if len(thisEntry.Host) > 1 {
-		fmt.Printf("HTTP %s %s http://%s:%d%s\n",
-			thisEntry.Version, thisEntry.Method,thisEntry.Host, thisEntry.IdRespP, thisEntry.Uri)
+	requestUri := thisEntry.Uri
+	if len(requestUri) == 0 {
+		requestUri = "/"
+	}
+	var parsed *url.URL
+	if requestUri == "*" {
+		parsed = &url.URL{Path: "*"}
 	} else {
-		fmt.Printf("HTTP %s %s http://%s:%d%s\n",
-			thisEntry.Version, thisEntry.Method,thisEntry.IdRespH, thisEntry.IdRespP, thisEntry.Uri)
+		var err error
+		parsed, err = url.ParseRequestURI(requestUri)
+		if err != nil {
+			return nil, err
+		}
 	}
+	parsed.Scheme = "http"
+	parsed.Host = thisEntry.hostPort()
+	return parsed, nil
 }
 
-// ShortPrint will just print the DNS Query and response as a one liner
-func (thisEntry *HttpEntry) ShortPrint() {
-	if len(thisEntry.Host) > 1 {
-		fmt.Printf("[%s] %s -> http://%s:%d%s\n", thisEntry.TS, thisEntry.IdOrigH, thisEntry.Host, thisEntry.IdRespP, thisEntry.Uri)
-	} else {
-		fmt.Printf("[%s] %s -> http://%s:%d%s\n", thisEntry.TS, thisEntry.IdOrigH, thisEntry.IdRespH, thisEntry.IdRespP, thisEntry.Uri)
+// hostPort returns the host the request was for with the port added if it isn't the default.
+func (thisEntry *HttpEntry) hostPort() string {
+	host := thisEntry.Host
+	if len(host) == 0 {
+		host = thisEntry.IdRespH
+	}
+	if hostOnly, port, err := net.SplitHostPort(host); err == nil {
+		if port == "80" {
+			return bracketIPv6(hostOnly)
+		}
+		return host
+	}
+	host = strings.Trim(host, "[]")
+	if thisEntry.IdRespP > 0 && thisEntry.IdRespP != 80 {
+		return net.JoinHostPort(host, strconv.Itoa(thisEntry.IdRespP))
+	}
+	return bracketIPv6(host)
+}
+
+// isConnect tells if the request was a CONNECT to tunnel through a proxy.
+func (thisEntry *HttpEntry) isConnect() bool {
+	return strings.EqualFold(thisEntry.Method, "CONNECT")
+}
 
+// defaultPortForScheme returns the default port of the given URL scheme, blank if unknown.
+func defaultPortForScheme(givenScheme string) string {
+	switch strings.ToLower(givenScheme) {
+	case "http":
+		return "80"
+	case "https":
+		return "443"
 	}
+	return ""
+}
+
+// bracketIPv6 wraps IPv6 addresses in brackets as needed in a URL host.
+func bracketIPv6(givenHost string) string {
+	if strings.Contains(givenHost, ":") {
+		return "[" + givenHost + "]"
+	}
+	return givenHost
+}
+
+// URLString returns the full requested URL as a string, falling back to the host and raw URI
+// if the URI doesn't parse.  CONNECT requests give just the host and port (ie: example.net:443).
+func (thisEntry *HttpEntry) URLString() string {
+	if thisEntry.isConnect() {
+		return thisEntry.Uri
+	}
+	parsed, err := thisEntry.URL()
+	if err != nil {
+		return "http://" + thisEntry.hostPort() + thisEntry.Uri
+	}
+	return parsed.String()
+}
+
+// QueryParams returns the query parameters of the request, nil if there were none or the
+// query doesn't parse.
+func (thisEntry *HttpEntry) QueryParams() url.Values {
+	parsed, err := thisEntry.URL()
+	if err != nil || len(parsed.RawQuery) == 0 {
+		return nil
+	}
+	params, err := url.ParseQuery(parsed.RawQuery)
+	if err != nil {
+		return nil
+	}
+	return params
+}
+
+// QueryParam returns the first value of the given query parameter, blank if not present.
+func (thisEntry *HttpEntry) QueryParam(givenName string) string {
+	return thisEntry.QueryParams().Get(givenName)
+}
+
+// ParsedUserAgent returns the classified user agent of the request.
+func (thisEntry *HttpEntry) ParsedUserAgent() UserAgent {
+	return ParseUserAgent(thisEntry.UserAgent)
 }
 
 // ------------------------------
@@ -99,6 +225,11 @@ func thisLogEntryToHttpStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogF
 			if err != nil {
 				return
 			}
+		case "trans_depth":
+			HttpEntry.TransDepth, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
 		case "method":
 			HttpEntry.Method = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
 		case "host":
@@ -114,34 +245,49 @@ func thisLogEntryToHttpStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogF
 		case "origin":
 			HttpEntry.Origin = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
 		case "request_body_len":
-			HttpEntry.ReqLen, err = strconv.Atoi(thisField.value)
+			HttpEntry.ReqLen, err = optInt(thisField.value, givenLogOpts.unsetField)
 			if err != nil {
 				return
 			}
 		case "response_body_len":
-			HttpEntry.RespLen, err = strconv.Atoi(thisField.value)
+			HttpEntry.RespLen, err = optInt(thisField.value, givenLogOpts.unsetField)
 			if err != nil {
 				return
 			}
 		case "status_code":
-
-			if thisField.value == givenLogOpts.unsetField {
-				HttpEntry.StatusCode = 0
-			} else {
-				HttpEntry.StatusCode, err = strconv.Atoi(thisField.value)
-				if err != nil {
-					return
-				}
+			HttpEntry.StatusCode, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
 			}
 		case "status_msg":
 			HttpEntry.StatusMsg = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
-		case "orig_mime_types":
-			if thisField.value == givenLogOpts.unsetField {
-				HttpEntry.MimeTypes = append(HttpEntry.MimeTypes, "")
-			} else {
-				splitSlice := strings.Split(thisField.value, givenLogOpts.setSeparator)
-				HttpEntry.MimeTypes = splitSlice
+		case "info_code":
+			HttpEntry.InfoCode, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
 			}
+		case "info_msg":
+			HttpEntry.InfoMsg = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "tags":
+			HttpEntry.Tags = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "username":
+			HttpEntry.Username = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "password":
+			HttpEntry.Password = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "proxied":
+			HttpEntry.Proxied = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "orig_fuids":
+			HttpEntry.OrigFuids = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "orig_filenames":
+			HttpEntry.OrigFilenames = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "orig_mime_types":
+			HttpEntry.MimeTypes = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "resp_fuids":
+			HttpEntry.RespFuids = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "resp_filenames":
+			HttpEntry.RespFilenames = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "resp_mime_types":
+			HttpEntry.RespMimeTypes = strSliceNilIfUnset(thisField.value, givenLogOpts)
 		}
 	}
 	return
@@ -18,3 +18,81 @@ func TestThisLogEntryToHttpStruct(t *testing.T) {
 	}
 	assert.NoError(t, compErr)
 }
+
+func TestParseHttpLogFullSchema(t *testing.T) {
+	allHttp, err := ParseHttpLog("test_input/simple_http_full.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(allHttp))
+
+	download := allHttp[0]
+	assert.Equal(t, Some(1), download.TransDepth)
+	assert.Equal(t, Some(0), download.ReqLen)
+	assert.Equal(t, Some(482304), download.RespLen)
+	assert.False(t, download.InfoCode.IsSet())
+	assert.Nil(t, download.Tags)
+	assert.Nil(t, download.MimeTypes)
+	assert.Equal(t, []string{"VIA -> 1.1 proxy.local", "X-FORWARDED-FOR -> 192.168.1.110"}, download.Proxied)
+	assert.Equal(t, []string{"application/x-dosexec"}, download.RespMimeTypes)
+	assert.Equal(t, []string{"FHtf1a2b3c4d5e6f7g"}, download.RespFuids)
+	assert.Equal(t, []string{"setup.exe"}, download.RespFilenames)
+
+	// unset counts are left unset rather than an error
+	upload := allHttp[1]
+	assert.False(t, upload.ReqLen.IsSet())
+	assert.False(t, upload.RespLen.IsSet())
+	assert.False(t, upload.StatusCode.IsSet())
+	assert.Equal(t, Some(100), upload.InfoCode)
+	assert.Equal(t, "Continue", upload.InfoMsg)
+	assert.Equal(t, []string{"HTTP::URI_SQLI"}, upload.Tags)
+	assert.Equal(t, "bob", upload.Username)
+	assert.Equal(t, []string{"FHtf2b3c4d5e6f7g1a"}, upload.OrigFuids)
+	assert.Equal(t, []string{"report.pdf"}, upload.OrigFilenames)
+	assert.Equal(t, []string{"application/pdf"}, upload.MimeTypes)
+	// zeek only logs a filename for files that had one so these don't line up with the fuids
+	assert.Equal(t, []string{"FHtf3c4d5e6f7g1a2b", "FHtf4d5e6f7g1a2b3c"}, upload.RespFuids)
+	assert.Equal(t, []string{"index.html"}, upload.RespFilenames)
+	assert.Equal(t, []string{"text/plain", "text/html"}, upload.RespMimeTypes)
+}
+
+func TestHttpURL(t *testing.T) {
+	allHttp, err := ParseHttpLog("test_input/simple_http_urls.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 10, len(allHttp))
+
+	search := allHttp[0]
+	assert.Equal(t, "http://example.com/search?q=zeek+logs&page=2&tag=a&tag=b", search.URLString())
+	assert.Equal(t, "zeek logs", search.QueryParam("q"))
+	assert.Equal(t, []string{"a", "b"}, search.QueryParams()["tag"])
+	assert.Equal(t, "", search.QueryParam("missing"))
+
+	// absolute-form uri sent to a proxy is used as is
+	proxied, err := allHttp[1].URL()
+	assert.NoError(t, err)
+	assert.Equal(t, "example.org", proxied.Host)
+	assert.Equal(t, "/index.html", proxied.Path)
+	assert.Equal(t, "proxy", allHttp[1].QueryParam("ref"))
+
+	connect, err := allHttp[2].URL()
+	assert.NoError(t, err)
+	assert.Equal(t, "example.net:443", connect.Host)
+	assert.Equal(t, "example.net:443", allHttp[2].URLString())
+	assert.Nil(t, allHttp[2].QueryParams())
+
+	// no host header falls back to the responder, non default ports are kept
+	assert.Equal(t, "http://198.51.100.7:8080/api/v1/status", allHttp[3].URLString())
+	assert.Equal(t, "http://[2001:db8::80]:8080/health", allHttp[4].URLString())
+	assert.Equal(t, "http://example.com/index.html", allHttp[5].URLString())
+
+	_, err = allHttp[6].URL()
+	assert.Error(t, err)
+	assert.Equal(t, "http://example.com/bad%zzescape", allHttp[6].URLString())
+	assert.Nil(t, allHttp[6].QueryParams())
+
+	// default ports are dropped from absolute-form uris too, the scheme is compared ignoring case
+	assert.Equal(t, "http://example.org/x", allHttp[7].URLString())
+	secure, err := allHttp[8].URL()
+	assert.NoError(t, err)
+	assert.Equal(t, "secure.example.org", secure.Host)
+	assert.Equal(t, "/", allHttp[8].QueryParam("next"))
+	assert.Equal(t, "https://[2001:db8::443]:8443/admin", allHttp[9].URLString())
+}
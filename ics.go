@@ -0,0 +1,107 @@
+/*
+Summarises which ICS master talks to which outstation with which function codes out of
+modbus.log and dnp3.log.  Useful to baseline allowed OT behaviour and spot new peers or
+new (especially write or control) function codes.
+*/
+
+package zeekparse
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// IcsPeerSummary is the function codes one master used against one outstation over one protocol.
+type IcsPeerSummary struct {
+	Protocol   string         // modbus or dnp3
+	Master     string         // originating host
+	Outstation string         // responding host
+	Functions  map[string]int // function name -> number of times used
+	Writes     int            // number of modbus writes or dnp3 control functions
+	Exceptions int            // number of modbus exceptions or dnp3 responses with error indications
+	FirstSeen  time.Time
+	LastSeen   time.Time
+}
+
+func (s *IcsPeerSummary) Print() {
+	fmt.Printf("%s %s -> %s writes:%d exceptions:%d (%s--%s)\n", s.Protocol, s.Master, s.Outstation,
+		s.Writes, s.Exceptions, s.FirstSeen.Format("01/02/06"), s.LastSeen.Format("01/02/06"))
+	for _, thisName := range s.FunctionNames() {
+		fmt.Printf("\t%s: %d\n", thisName, s.Functions[thisName])
+	}
+}
+
+// FunctionNames returns the sorted names of the functions used.
+func (s *IcsPeerSummary) FunctionNames() (names []string) {
+	for thisName := range s.Functions {
+		names = append(names, thisName)
+	}
+	sort.Strings(names)
+	return
+}
+
+// BuildIcsSummary summarises the given modbus and dnp3 entries per protocol, master and
+// outstation.  Results are sorted by protocol, master and then outstation.
+func BuildIcsSummary(givenModbus []ModbusEntry, givenDnp3 []Dnp3Entry) (allSummaries []*IcsPeerSummary) {
+	byPeers := make(map[string]*IcsPeerSummary)
+	getSummary := func(givenProtocol, givenMaster, givenOutstation string, givenTS time.Time) *IcsPeerSummary {
+		key := givenProtocol + "|" + givenMaster + "|" + givenOutstation
+		s, ok := byPeers[key]
+		if !ok {
+			s = &IcsPeerSummary{
+				Protocol:   givenProtocol,
+				Master:     givenMaster,
+				Outstation: givenOutstation,
+				Functions:  make(map[string]int),
+			}
+			byPeers[key] = s
+			allSummaries = append(allSummaries, s)
+		}
+		updateSeen(&s.FirstSeen, &s.LastSeen, givenTS)
+		return s
+	}
+
+	for _, thisModbus := range givenModbus {
+		s := getSummary("modbus", thisModbus.IdOrigH, thisModbus.IdRespH, thisModbus.TS)
+		if thisModbus.IsException() {
+			s.Exceptions++
+		}
+		// responses repeat the function of the request so only count each once.  Zeek versions
+		// without pdu_type log both directions so those will count twice.
+		if thisModbus.PduType == "RESP" || len(thisModbus.FuncName) == 0 {
+			continue
+		}
+		s.Functions[strings.TrimSuffix(thisModbus.FuncName, "_EXCEPTION")]++
+		if thisModbus.Func.IsWrite() {
+			s.Writes++
+		}
+	}
+
+	for _, thisDnp3 := range givenDnp3 {
+		s := getSummary("dnp3", thisDnp3.IdOrigH, thisDnp3.IdRespH, thisDnp3.TS)
+		if thisDnp3.IIN.IsException() {
+			s.Exceptions++
+		}
+		if len(thisDnp3.FcRequestName) == 0 {
+			continue
+		}
+		s.Functions[thisDnp3.FcRequestName]++
+		if thisDnp3.FcRequest.IsControl() {
+			s.Writes++
+		}
+	}
+
+	sort.Slice(allSummaries, func(i, j int) bool {
+		a, b := allSummaries[i], allSummaries[j]
+		if a.Protocol != b.Protocol {
+			return a.Protocol < b.Protocol
+		}
+		if a.Master != b.Master {
+			return a.Master < b.Master
+		}
+		return a.Outstation < b.Outstation
+	})
+	return
+}
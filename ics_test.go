@@ -0,0 +1,31 @@
+package zeekparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBuildIcsSummary(t *testing.T) {
+	allModbus, err := ParseModbusLog("test_input/simple_modbus.log")
+	assert.NoError(t, err)
+	allDnp3, err := ParseDnp3Log("test_input/simple_dnp3.log")
+	assert.NoError(t, err)
+
+	summaries := BuildIcsSummary(allModbus, allDnp3)
+	assert.Equal(t, 3, len(summaries))
+
+	assert.Equal(t, "dnp3", summaries[0].Protocol)
+	assert.Equal(t, []string{"DIRECT_OPERATE", "READ"}, summaries[0].FunctionNames())
+	assert.Equal(t, 1, summaries[0].Writes)
+	assert.Equal(t, 1, summaries[0].Exceptions)
+
+	mainMaster := summaries[1]
+	assert.Equal(t, "10.20.0.10", mainMaster.Master)
+	assert.Equal(t, 1, mainMaster.Functions["READ_HOLDING_REGISTERS"])
+	assert.Equal(t, 1, mainMaster.Functions["WRITE_SINGLE_REGISTER"])
+	assert.Equal(t, 1, mainMaster.Writes)
+	assert.Equal(t, 1, mainMaster.Exceptions)
+
+	assert.Equal(t, "10.20.0.99", summaries[2].Master)
+	assert.Equal(t, 1, summaries[2].Functions["unknown-90"])
+}
@@ -0,0 +1,339 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// intel log format described in https://docs.zeek.org/en/master/scripts/base/frameworks/intel/main.zeek.html#type-Intel::Info
+// intel input file format described in https://docs.zeek.org/en/master/frameworks/intel.html
+
+// ------------------------------
+// ----- Helper Structures ------
+// ------------------------------
+
+// IntelType is an enum of the indicator types zeek's intel framework understands.
+type IntelType string
+
+const (
+	IntelAddr       IntelType = "Intel::ADDR"
+	IntelSubnet     IntelType = "Intel::SUBNET"
+	IntelURL        IntelType = "Intel::URL"
+	IntelSoftware   IntelType = "Intel::SOFTWARE"
+	IntelEmail      IntelType = "Intel::EMAIL"
+	IntelDomain     IntelType = "Intel::DOMAIN"
+	IntelUserName   IntelType = "Intel::USER_NAME"
+	IntelCertHash   IntelType = "Intel::CERT_HASH"
+	IntelPubkeyHash IntelType = "Intel::PUBKEY_HASH"
+	IntelFileHash   IntelType = "Intel::FILE_HASH"
+	IntelFileName   IntelType = "Intel::FILE_NAME"
+)
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// IntelEntry is a fully parsed intel.log line.
+type IntelEntry struct {
+	TS      time.Time // TS:time - timestamp
+	Uid     string    // Uid:string - unique id
+	IdOrigH string    // id_orig_h:addr - senders address
+	IdOrigP int       // id_orig_p:addr - senders port
+	IdRespH string    // id_resp_h:port - responders address
+	IdRespP int       // id_resp_p:port - responders port
+	// ---------
+	SeenIndicator     string    // seen.indicator:string - the intelligence indicator that was seen
+	SeenIndicatorType IntelType // seen.indicator_type:enum - the type of data that the indicator represents
+	SeenWhere         string    // seen.where:enum - where the data was discovered (ie: HTTP::IN_HOST_HEADER)
+	SeenNode          string    // seen.node:string - name of the node where the match was discovered
+	Matched           []string  // matched:set[enum] - which indicator types matched
+	Sources           []string  // sources:set[string] - sources which supplied data that resulted in this match
+	Fuid              string    // fuid:string - if a file was associated with this intelligence hit, this is the uid for the file
+	FileMimeType      string    // file_mime_type:string - mime type if the intelligence hit is related to a file
+	FileDesc          string    // file_desc:string - frequently files can be described to give a bit more context
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (i *IntelEntry) Print() {
+	fmt.Printf("(%s) client {%s:%d} talks to {%s:%d}:\n",
+		i.TS.String(), i.IdOrigH, i.IdOrigP, i.IdRespH, i.IdRespP)
+	fmt.Printf("\tintel hit %s (%s) at %s sources:%s\n", i.SeenIndicator, i.SeenIndicatorType, i.SeenWhere, i.Sources)
+}
+
+func (i *IntelEntry) ShortPrint() {
+	fmt.Printf("[%s] %s %s %s\n", i.TS, i.IdOrigH, i.SeenIndicator, i.Sources)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToIntelStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (intelEntry IntelEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			intelEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "uid":
+			intelEntry.Uid = thisField.value
+		case "id.orig_h":
+			intelEntry.IdOrigH = thisField.value
+		case "id.orig_p":
+			intelEntry.IdOrigP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id.resp_h":
+			intelEntry.IdRespH = thisField.value
+		case "id.resp_p":
+			intelEntry.IdRespP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "seen.indicator":
+			intelEntry.SeenIndicator = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "seen.indicator_type":
+			intelEntry.SeenIndicatorType = IntelType(StrBlankIfUnset(thisField.value, givenLogOpts.unsetField))
+		case "seen.where":
+			intelEntry.SeenWhere = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "seen.node":
+			intelEntry.SeenNode = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "matched":
+			intelEntry.Matched = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "sources":
+			intelEntry.Sources = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "fuid":
+			intelEntry.Fuid = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "file_mime_type":
+			intelEntry.FileMimeType = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "file_desc":
+			intelEntry.FileDesc = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseIntelLog will parse through the given single intel log (passed as a filename string)
+func ParseIntelLog(givenFilename string) (parsedResults []IntelEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes IntelEntry
+		thisRes, err = thisLogEntryToIntelStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseIntelRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseIntelRecurse(givenDirectory string) (allResults []IntelEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "intel") {
+		thisResult, parseErr := ParseIntelLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllIntelForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed IntelEntry objects
+func GetAllIntelForDay(givenDay string, givenZeekDir ...string) (allRes []IntelEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseIntelRecurse(zeekDir + givenDay + "/")
+	return
+}
+
+// ------------------------------
+// ----  Intel Input Files  -----
+// ------------------------------
+
+// IntelItem is a single indicator from a zeek intel input file.  Meta holds the meta.* columns
+// keyed without the "meta." prefix (ie: source, desc, url).
+type IntelItem struct {
+	Indicator     string
+	IndicatorType IntelType
+	Meta          map[string]string
+}
+
+// intel input files are read by zeek's input framework which falls back to these when the
+// file doesn't set them in its header.
+func defaultIntelFileOpts() *LogFileOpts {
+	return &LogFileOpts{
+		separator:    "\t",
+		setSeparator: ",",
+		emptyField:   "(empty)",
+		unsetField:   "-",
+		fieldTypeMap: make(map[string]string),
+	}
+}
+
+// ReadIntelFile will parse the given zeek intel input file (passed as a filename string).
+// Both plain and gzipped files are handled.
+func ReadIntelFile(givenFilename string) (allItems []IntelItem, err error) {
+	scanner, fHnd, gzipReader, fileSetupErr := setUpFileParse(givenFilename)
+	if fHnd != nil {
+		defer fHnd.Close()
+	}
+	if gzipReader != nil {
+		defer gzipReader.Close()
+	}
+	if fileSetupErr != nil {
+		err = fileSetupErr
+		return
+	}
+
+	opts := defaultIntelFileOpts()
+	for scanner.Scan() {
+		thisLine := scanner.Text()
+		if len(strings.TrimSpace(thisLine)) == 0 {
+			continue
+		}
+
+		if strings.HasPrefix(thisLine, "#separator") {
+			opts.separator = zeekLogLineToSeparator(thisLine)
+			continue
+		}
+		if strings.HasPrefix(thisLine, "#fields") {
+			opts.fieldOrder = strings.Split(thisLine, opts.separator)[1:]
+			continue
+		}
+		if strings.HasPrefix(thisLine, "#") {
+			thisFieldName, thisFieldValue := zeekLogPullVar(thisLine, opts.separator)
+			switch thisFieldName {
+			case "set_separator":
+				opts.setSeparator = unescapeFieldValue(thisFieldValue)
+			case "unset_field":
+				opts.unsetField = unescapeFieldValue(thisFieldValue)
+			case "empty_field":
+				opts.emptyField = unescapeFieldValue(thisFieldValue)
+			}
+			continue
+		}
+
+		if len(opts.fieldOrder) == 0 {
+			err = errors.New("intel file has no fields header")
+			return
+		}
+		thisLineSplit := strings.Split(thisLine, opts.separator)
+		if len(thisLineSplit) != len(opts.fieldOrder) {
+			err = errors.New("mismatch between line in intel file and fields in header")
+			return
+		}
+
+		thisItem := IntelItem{Meta: make(map[string]string)}
+		for idx, thisFieldName := range opts.fieldOrder {
+			thisValue := StrBlankIfUnset(thisLineSplit[idx], opts.unsetField)
+			switch {
+			case thisFieldName == "indicator":
+				thisItem.Indicator = thisValue
+			case thisFieldName == "indicator_type":
+				thisItem.IndicatorType = IntelType(thisValue)
+			case strings.HasPrefix(thisFieldName, "meta."):
+				if len(thisValue) > 0 {
+					thisItem.Meta[strings.TrimPrefix(thisFieldName, "meta.")] = thisValue
+				}
+			}
+		}
+		allItems = append(allItems, thisItem)
+	}
+	err = scanner.Err()
+	return
+}
+
+// WriteIntel will write the given items to the writer in zeek's intel input file format.  The
+// meta columns are the union of all the items meta keys with source, desc and url first.
+func WriteIntel(givenWriter io.Writer, givenItems []IntelItem) (err error) {
+	var metaKeys []string
+	for _, thisKey := range []string{"source", "desc", "url"} {
+		for _, thisItem := range givenItems {
+			if _, ok := thisItem.Meta[thisKey]; ok {
+				metaKeys = append(metaKeys, thisKey)
+				break
+			}
+		}
+	}
+	var otherKeys []string
+	for _, thisItem := range givenItems {
+		for thisKey := range thisItem.Meta {
+			if thisKey != "source" && thisKey != "desc" && thisKey != "url" {
+				otherKeys = appendUnique(otherKeys, thisKey)
+			}
+		}
+	}
+	sort.Strings(otherKeys)
+	metaKeys = append(metaKeys, otherKeys...)
+
+	opts := defaultIntelFileOpts()
+	header := []string{"#fields", "indicator", "indicator_type"}
+	for _, thisKey := range metaKeys {
+		header = append(header, "meta."+thisKey)
+	}
+	if _, err = fmt.Fprintln(givenWriter, strings.Join(header, opts.separator)); err != nil {
+		return
+	}
+
+	for _, thisItem := range givenItems {
+		row := []string{thisItem.Indicator, string(thisItem.IndicatorType)}
+		for _, thisKey := range metaKeys {
+			row = append(row, thisItem.Meta[thisKey])
+		}
+		for idx, thisValue := range row {
+			if strings.ContainsAny(thisValue, "\t\n") {
+				err = errors.New("intel values can't contain tabs or newlines")
+				return
+			}
+			if len(thisValue) == 0 {
+				row[idx] = opts.unsetField
+			}
+		}
+		if _, err = fmt.Fprintln(givenWriter, strings.Join(row, opts.separator)); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// WriteIntelFile will write the given items to the given filename in zeek's intel input file format.
+func WriteIntelFile(givenFilename string, givenItems []IntelItem) (err error) {
+	fHnd, err := os.Create(givenFilename)
+	if err != nil {
+		return
+	}
+	err = WriteIntel(fHnd, givenItems)
+	closeErr := fHnd.Close()
+	if err == nil {
+		err = closeErr
+	}
+	return
+}
@@ -0,0 +1,58 @@
+package zeekparse
+
+import (
+	"bytes"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestThisLogEntryToIntelStruct(t *testing.T) {
+	log.SetFormatter(&log.TextFormatter{ForceColors: true})
+	log.SetLevel(log.InfoLevel)
+
+	// compressed case
+	compressedResults, header, compErr := parseZeekLog("test_input/simple_intel.log.gz")
+	for _, thisResult := range compressedResults {
+		_, intelErr := thisLogEntryToIntelStruct(thisResult, header)
+		assert.NoError(t, intelErr)
+	}
+	assert.NoError(t, compErr)
+
+	allIntel, err := ParseIntelLog("test_input/simple_intel.log")
+	assert.NoError(t, err)
+	assert.Equal(t, IntelDomain, allIntel[0].SeenIndicatorType)
+	assert.Equal(t, []string{"ti-platform", "abuse-feed"}, allIntel[0].Sources)
+}
+
+func TestReadWriteIntel(t *testing.T) {
+	allItems, err := ReadIntelFile("test_input/intel_input.dat")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(allItems))
+	assert.Equal(t, IntelAddr, allItems[0].IndicatorType)
+	assert.Equal(t, "known c2 server", allItems[0].Meta["desc"])
+	_, hasURL := allItems[0].Meta["url"]
+	assert.False(t, hasURL)
+
+	// writing and reading back should round trip
+	allItems = append(allItems, IntelItem{Indicator: "bad@example.com", IndicatorType: IntelEmail,
+		Meta: map[string]string{"source": "analyst", "do_notice": "T"}})
+	var buf bytes.Buffer
+	assert.NoError(t, WriteIntel(&buf, allItems))
+	assert.Contains(t, buf.String(), "#fields\tindicator\tindicator_type\tmeta.source\tmeta.desc\tmeta.url\tmeta.do_notice\n")
+	assert.Contains(t, buf.String(), "198.51.100.66\tIntel::ADDR\tti-platform\tknown c2 server\t-\t-\n")
+
+	tmpDir, err := ioutil.TempDir("", "zeekparse-intel")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+	tmpFilename := tmpDir + "/intel.dat"
+	assert.NoError(t, WriteIntelFile(tmpFilename, allItems))
+	readBack, err := ReadIntelFile(tmpFilename)
+	assert.NoError(t, err)
+	assert.Equal(t, allItems, readBack)
+
+	badItems := []IntelItem{{Indicator: "a\tb", IndicatorType: IntelDomain}}
+	assert.Error(t, WriteIntel(&buf, badItems))
+}
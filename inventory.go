@@ -0,0 +1,151 @@
+/*
+Builds a per-host asset inventory out of software.log and the known_* logs.  Each host
+gets the services, software versions and certificates seen on it along with when they
+were first and last seen.
+*/
+
+package zeekparse
+
+import (
+	"fmt"
+	"time"
+)
+
+// InventoryService is a service seen listening on a host.
+type InventoryService struct {
+	Port      Opt[int]
+	Proto     Proto
+	Services  []string
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// InventorySoftware is a piece of software seen on a host.  Version is the newest version seen.
+type InventorySoftware struct {
+	SoftwareType string
+	Name         string
+	Version      SoftwareVersion
+	Port         Opt[int]
+	FirstSeen    time.Time
+	LastSeen     time.Time
+}
+
+// InventoryCert is a certificate served by a host.
+type InventoryCert struct {
+	Port          Opt[int]
+	Subject       string
+	IssuerSubject string
+	Serial        string
+	FirstSeen     time.Time
+	LastSeen      time.Time
+}
+
+// HostInventory is everything known about a single host.
+type HostInventory struct {
+	Host      string
+	FirstSeen time.Time
+	LastSeen  time.Time
+	Services  map[string]*InventoryService  // keyed by port/proto (ie: 443/TCP)
+	Software  map[string]*InventorySoftware // keyed by software type and name (ie: HTTP::SERVER/nginx)
+	Certs     map[string]*InventoryCert     // keyed by port and serial
+}
+
+func (h *HostInventory) Print() {
+	fmt.Printf("%s (%s--%s)\n", h.Host, h.FirstSeen.Format("01/02/06"), h.LastSeen.Format("01/02/06"))
+	for key, thisService := range h.Services {
+		fmt.Printf("\tservice %s %s\n", key, thisService.Services)
+	}
+	for _, thisSoftware := range h.Software {
+		fmt.Printf("\tsoftware %s %s %s\n", thisSoftware.SoftwareType, thisSoftware.Name, thisSoftware.Version.String())
+	}
+	for _, thisCert := range h.Certs {
+		fmt.Printf("\tcert %s %s\n", thisCert.Port, thisCert.Subject)
+	}
+}
+
+// widen the given first/last seen window to include the given time.
+func updateSeen(givenFirst, givenLast *time.Time, givenTS time.Time) {
+	if givenFirst.IsZero() || givenTS.Before(*givenFirst) {
+		*givenFirst = givenTS
+	}
+	if givenTS.After(*givenLast) {
+		*givenLast = givenTS
+	}
+}
+
+// BuildInventory merges the given known_hosts, known_services, known_certs and software entries
+// into a per-host inventory keyed by host address.
+func BuildInventory(givenHosts []KnownHostsEntry, givenServices []KnownServicesEntry,
+	givenCerts []KnownCertsEntry, givenSoftware []SoftwareEntry) map[string]*HostInventory {
+
+	inventory := make(map[string]*HostInventory)
+	getHost := func(givenHost string, givenTS time.Time) *HostInventory {
+		h, ok := inventory[givenHost]
+		if !ok {
+			h = &HostInventory{
+				Host:     givenHost,
+				Services: make(map[string]*InventoryService),
+				Software: make(map[string]*InventorySoftware),
+				Certs:    make(map[string]*InventoryCert),
+			}
+			inventory[givenHost] = h
+		}
+		updateSeen(&h.FirstSeen, &h.LastSeen, givenTS)
+		return h
+	}
+
+	for _, thisHost := range givenHosts {
+		getHost(thisHost.Host, thisHost.TS)
+	}
+
+	for _, thisService := range givenServices {
+		h := getHost(thisService.Host, thisService.TS)
+		key := thisService.PortNum.String() + "/" + string(thisService.PortProto)
+		s, ok := h.Services[key]
+		if !ok {
+			s = &InventoryService{Port: thisService.PortNum, Proto: thisService.PortProto}
+			h.Services[key] = s
+		}
+		for _, thisName := range thisService.Service {
+			s.Services = appendUnique(s.Services, thisName)
+		}
+		updateSeen(&s.FirstSeen, &s.LastSeen, thisService.TS)
+	}
+
+	for _, thisCert := range givenCerts {
+		h := getHost(thisCert.Host, thisCert.TS)
+		key := thisCert.PortNum.String() + "/" + thisCert.Serial
+		c, ok := h.Certs[key]
+		if !ok {
+			c = &InventoryCert{
+				Port:          thisCert.PortNum,
+				Subject:       thisCert.Subject,
+				IssuerSubject: thisCert.IssuerSubject,
+				Serial:        thisCert.Serial,
+			}
+			h.Certs[key] = c
+		}
+		updateSeen(&c.FirstSeen, &c.LastSeen, thisCert.TS)
+	}
+
+	for _, thisSoftware := range givenSoftware {
+		h := getHost(thisSoftware.Host, thisSoftware.TS)
+		key := thisSoftware.SoftwareType + "/" + thisSoftware.Name
+		s, ok := h.Software[key]
+		if !ok {
+			s = &InventorySoftware{
+				SoftwareType: thisSoftware.SoftwareType,
+				Name:         thisSoftware.Name,
+				Version:      thisSoftware.Version,
+				Port:         thisSoftware.HostP,
+			}
+			h.Software[key] = s
+		} else if thisSoftware.Version.Compare(s.Version) > 0 {
+			s.Version = thisSoftware.Version
+			s.Port = thisSoftware.HostP
+		}
+		updateSeen(&s.FirstSeen, &s.LastSeen, thisSoftware.TS)
+	}
+
+	return inventory
+}
@@ -0,0 +1,33 @@
+package zeekparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBuildInventory(t *testing.T) {
+	allHosts, err := ParseKnownHostsLog("test_input/simple_known_hosts.log")
+	assert.NoError(t, err)
+	allServices, err := ParseKnownServicesLog("test_input/simple_known_services.log")
+	assert.NoError(t, err)
+	allCerts, err := ParseKnownCertsLog("test_input/simple_known_certs.log")
+	assert.NoError(t, err)
+	allSoftware, err := ParseSoftwareLog("test_input/simple_software.log")
+	assert.NoError(t, err)
+
+	inventory := BuildInventory(allHosts, allServices, allCerts, allSoftware)
+	assert.Equal(t, 3, len(inventory))
+
+	nas := inventory["192.168.1.50"]
+	assert.Equal(t, 2, len(nas.Services))
+	https := nas.Services["443/TCP"]
+	assert.Equal(t, []string{"SSL", "HTTP"}, https.Services)
+	assert.True(t, https.LastSeen.After(https.FirstSeen))
+
+	// the newest nginx version wins
+	assert.Equal(t, "1.18.0", nas.Software["HTTP::SERVER/nginx"].Version.String())
+	assert.Equal(t, 1, len(nas.Certs))
+	assert.Equal(t, int64(1621141231), nas.LastSeen.Unix())
+
+	assert.Equal(t, UDP, inventory["192.168.1.60"].Services["53/UDP"].Proto)
+}
@@ -0,0 +1,152 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"time"
+)
+
+// irc log format described in https://docs.zeek.org/en/master/scripts/base/protocols/irc/main.zeek.html#type-IRC::Info
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// IrcEntry is a fully parsed irc.log line.
+type IrcEntry struct {
+	TS      time.Time // TS:time - timestamp
+	Uid     string    // Uid:string - unique id
+	IdOrigH string    // id_orig_h:addr - senders address
+	IdOrigP int       // id_orig_p:addr - senders port
+	IdRespH string    // id_resp_h:port - responders address
+	IdRespP int       // id_resp_p:port - responders port
+	// ---------
+	Nick        string   // nick:string - nickname given for the connection
+	User        string   // user:string - username given for the connection
+	Command     string   // command:string - command given by the client
+	Value       string   // value:string - value for the command given by the client
+	Addl        string   // addl:string - any additional data for the command
+	DccFileName string   // dcc_file_name:string - DCC filename requested
+	DccFileSize Opt[int] // dcc_file_size:count - size of the DCC transfer as indicated by the sender
+	DccMimeType string   // dcc_mime_type:string - sniffed mime type of the file
+	Fuid        string   // fuid:string - file unique ID
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (i *IrcEntry) Print() {
+	fmt.Printf("(%s) client {%s:%d} talks to {%s:%d}:\n",
+		i.TS.String(), i.IdOrigH, i.IdOrigP, i.IdRespH, i.IdRespP)
+	fmt.Printf("\t%s (%s): %s %s %s\n", i.Nick, i.User, i.Command, i.Value, i.Addl)
+}
+
+func (i *IrcEntry) ShortPrint() {
+	fmt.Printf("[%s] %s %s %s %s\n", i.TS, i.IdOrigH, i.Nick, i.Command, i.Value)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToIrcStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (ircEntry IrcEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			ircEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "uid":
+			ircEntry.Uid = thisField.value
+		case "id.orig_h":
+			ircEntry.IdOrigH = thisField.value
+		case "id.orig_p":
+			ircEntry.IdOrigP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id.resp_h":
+			ircEntry.IdRespH = thisField.value
+		case "id.resp_p":
+			ircEntry.IdRespP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "nick":
+			ircEntry.Nick = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "user":
+			ircEntry.User = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "command":
+			ircEntry.Command = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "value":
+			ircEntry.Value = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "addl":
+			ircEntry.Addl = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "dcc_file_name":
+			ircEntry.DccFileName = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "dcc_file_size":
+			ircEntry.DccFileSize, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "dcc_mime_type":
+			ircEntry.DccMimeType = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "fuid":
+			ircEntry.Fuid = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseIrcLog will parse through the given single irc log (passed as a filename string)
+func ParseIrcLog(givenFilename string) (parsedResults []IrcEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes IrcEntry
+		thisRes, err = thisLogEntryToIrcStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseIrcRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseIrcRecurse(givenDirectory string) (allResults []IrcEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "irc") {
+		thisResult, parseErr := ParseIrcLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllIrcForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed IrcEntry objects
+func GetAllIrcForDay(givenDay string, givenZeekDir ...string) (allRes []IrcEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseIrcRecurse(zeekDir + givenDay + "/")
+	return
+}
@@ -0,0 +1,28 @@
+package zeekparse
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThisLogEntryToIrcStruct(t *testing.T) {
+	log.SetFormatter(&log.TextFormatter{ForceColors: true})
+	log.SetLevel(log.InfoLevel)
+
+	// compressed case
+	compressedResults, header, compErr := parseZeekLog("test_input/simple_irc.log.gz")
+	for _, thisResult := range compressedResults {
+		_, parseErr := thisLogEntryToIrcStruct(thisResult, header)
+		assert.NoError(t, parseErr)
+	}
+	assert.NoError(t, compErr)
+
+	allIrc, err := ParseIrcLog("test_input/simple_irc.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(allIrc))
+	assert.Equal(t, "", allIrc[0].Nick)
+	assert.Equal(t, "NICK", allIrc[0].Command)
+	assert.Equal(t, "#control", allIrc[2].Value)
+	assert.False(t, allIrc[2].DccFileSize.IsSet())
+}
@@ -0,0 +1,184 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// kerberos log format described in https://docs.zeek.org/en/master/scripts/base/protocols/krb/main.zeek.html#type-KRB::Info
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// KerberosEntry is a fully parsed kerberos.log line.
+type KerberosEntry struct {
+	TS      time.Time // TS:time - timestamp
+	Uid     string    // Uid:string - unique id
+	IdOrigH string    // id_orig_h:addr - senders address
+	IdOrigP int       // id_orig_p:addr - senders port
+	IdRespH string    // id_resp_h:port - responders address
+	IdRespP int       // id_resp_p:port - responders port
+	// ---------
+	RequestType       string    // request_type:string - Authentication Service ("AS") or Ticket Granting Service ("TGS")
+	Client            string    // client:string - client principal (ie: user/REALM)
+	Service           string    // service:string - service principal
+	Success           bool      // success:bool - request result
+	ErrorMsg          string    // error_msg:string - error message if the request failed
+	From              time.Time // from:time - ticket valid from
+	Till              time.Time // till:time - ticket valid until
+	Cipher            string    // cipher:string - ticket encryption type
+	Forwardable       bool      // forwardable:bool - forwardable ticket requested
+	Renewable         bool      // renewable:bool - renewable ticket requested
+	ClientCertSubject string    // client_cert_subject:string - subject of client certificate if any
+	ClientCertFuid    string    // client_cert_fuid:string - file unique id of client certificate if any
+	ServerCertSubject string    // server_cert_subject:string - subject of server certificate if any
+	ServerCertFuid    string    // server_cert_fuid:string - file unique id of server certificate if any
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (k *KerberosEntry) Print() {
+	fmt.Printf("(%s) client {%s:%d} talks to {%s:%d}:\n",
+		k.TS.String(), k.IdOrigH, k.IdOrigP, k.IdRespH, k.IdRespP)
+	fmt.Printf("\t%s %s -> %s success:%t %s\n", k.RequestType, k.Client, k.Service, k.Success, k.ErrorMsg)
+}
+
+func (k *KerberosEntry) ShortPrint() {
+	fmt.Printf("[%s] %s %s %s -> %s\n", k.TS, k.IdOrigH, k.RequestType, k.Client, k.Service)
+}
+
+// ------------------------------
+// ----    Entry Helpers   ------
+// ------------------------------
+
+// ClientUser returns the user portion of the client principal (ie: "jdoe" for "jdoe/CORP.LOCAL").
+func (k *KerberosEntry) ClientUser() string {
+	return strings.SplitN(k.Client, "/", 2)[0]
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToKerberosStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (kerberosEntry KerberosEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			kerberosEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "uid":
+			kerberosEntry.Uid = thisField.value
+		case "id.orig_h":
+			kerberosEntry.IdOrigH = thisField.value
+		case "id.orig_p":
+			kerberosEntry.IdOrigP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id.resp_h":
+			kerberosEntry.IdRespH = thisField.value
+		case "id.resp_p":
+			kerberosEntry.IdRespP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "request_type":
+			kerberosEntry.RequestType = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "client":
+			kerberosEntry.Client = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "service":
+			kerberosEntry.Service = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "success":
+			kerberosEntry.Success = thisField.value == "T"
+		case "error_msg":
+			kerberosEntry.ErrorMsg = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "from":
+			if thisField.value != givenLogOpts.unsetField {
+				kerberosEntry.From, err = UnixStrToTime(thisField.value)
+				if err != nil {
+					return
+				}
+			}
+		case "till":
+			if thisField.value != givenLogOpts.unsetField {
+				kerberosEntry.Till, err = UnixStrToTime(thisField.value)
+				if err != nil {
+					return
+				}
+			}
+		case "cipher":
+			kerberosEntry.Cipher = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "forwardable":
+			kerberosEntry.Forwardable = thisField.value == "T"
+		case "renewable":
+			kerberosEntry.Renewable = thisField.value == "T"
+		case "client_cert_subject":
+			kerberosEntry.ClientCertSubject = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "client_cert_fuid":
+			kerberosEntry.ClientCertFuid = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "server_cert_subject":
+			kerberosEntry.ServerCertSubject = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "server_cert_fuid":
+			kerberosEntry.ServerCertFuid = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseKerberosLog will parse through the given single kerberos log (passed as a filename string)
+func ParseKerberosLog(givenFilename string) (parsedResults []KerberosEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes KerberosEntry
+		thisRes, err = thisLogEntryToKerberosStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseKerberosRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseKerberosRecurse(givenDirectory string) (allResults []KerberosEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "kerberos") {
+		thisResult, parseErr := ParseKerberosLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllKerberosForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed KerberosEntry objects
+func GetAllKerberosForDay(givenDay string, givenZeekDir ...string) (allRes []KerberosEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseKerberosRecurse(zeekDir + givenDay + "/")
+	return
+}
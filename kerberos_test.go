@@ -0,0 +1,31 @@
+package zeekparse
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThisLogEntryToKerberosStruct(t *testing.T) {
+	log.SetFormatter(&log.TextFormatter{ForceColors: true})
+	log.SetLevel(log.InfoLevel)
+
+	// compressed case
+	compressedResults, header, compErr := parseZeekLog("test_input/simple_kerberos.log.gz")
+	for _, thisResult := range compressedResults {
+		_, parseErr := thisLogEntryToKerberosStruct(thisResult, header)
+		assert.NoError(t, parseErr)
+	}
+	assert.NoError(t, compErr)
+}
+
+func TestParseKerberosLog(t *testing.T) {
+	allKrb, err := ParseKerberosLog("test_input/simple_kerberos.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(allKrb))
+	assert.Equal(t, "JDoe", allKrb[0].ClientUser())
+	assert.True(t, allKrb[0].From.IsZero())
+	assert.Equal(t, 2021, allKrb[0].Till.Year())
+	assert.False(t, allKrb[2].Success)
+	assert.Equal(t, "KDC_ERR_PREAUTH_FAILED", allKrb[2].ErrorMsg)
+}
@@ -0,0 +1,116 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+// known_certs log format described in https://docs.zeek.org/en/master/scripts/policy/protocols/ssl/known-certs.zeek.html#type-Known::CertsInfo
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// KnownCertsEntry is a fully parsed known_certs.log line.
+type KnownCertsEntry struct {
+	TS time.Time // TS:time - timestamp
+	// ---------
+	Host          string   // host:addr - address of the server
+	PortNum       Opt[int] // port_num:port - port number the server is listening on
+	Subject       string   // subject:string - certificate subject
+	IssuerSubject string   // issuer_subject:string - certificate issuer subject
+	Serial        string   // serial:string - serial number for the certificate
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (k *KnownCertsEntry) Print() {
+	fmt.Printf("(%s) known cert on %s:%s: %s issuer:%s\n", k.TS.String(), k.Host, k.PortNum, k.Subject, k.IssuerSubject)
+}
+
+func (k *KnownCertsEntry) ShortPrint() {
+	fmt.Printf("[%s] %s:%s %s\n", k.TS, k.Host, k.PortNum, k.Subject)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToKnownCertsStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (knownCertsEntry KnownCertsEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			knownCertsEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "host":
+			knownCertsEntry.Host = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "port_num":
+			knownCertsEntry.PortNum, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "subject":
+			knownCertsEntry.Subject = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "issuer_subject":
+			knownCertsEntry.IssuerSubject = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "serial":
+			knownCertsEntry.Serial = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseKnownCertsLog will parse through the given single known_certs log (passed as a filename string)
+func ParseKnownCertsLog(givenFilename string) (parsedResults []KnownCertsEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes KnownCertsEntry
+		thisRes, err = thisLogEntryToKnownCertsStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseKnownCertsRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseKnownCertsRecurse(givenDirectory string) (allResults []KnownCertsEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "known_certs") {
+		thisResult, parseErr := ParseKnownCertsLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllKnownCertsForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed KnownCertsEntry objects
+func GetAllKnownCertsForDay(givenDay string, givenZeekDir ...string) (allRes []KnownCertsEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseKnownCertsRecurse(zeekDir + givenDay + "/")
+	return
+}
@@ -0,0 +1,101 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+// known_hosts log format described in https://docs.zeek.org/en/master/scripts/policy/protocols/conn/known-hosts.zeek.html#type-Known::HostsInfo
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// KnownHostsEntry is a fully parsed known_hosts.log line.
+type KnownHostsEntry struct {
+	TS time.Time // TS:time - timestamp
+	// ---------
+	Host string // host:addr - address that was detected originating or responding to a TCP connection
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (k *KnownHostsEntry) Print() {
+	fmt.Printf("(%s) known host: %s\n", k.TS.String(), k.Host)
+}
+
+func (k *KnownHostsEntry) ShortPrint() {
+	fmt.Printf("[%s] %s\n", k.TS, k.Host)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToKnownHostsStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (knownHostsEntry KnownHostsEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			knownHostsEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "host":
+			knownHostsEntry.Host = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseKnownHostsLog will parse through the given single known_hosts log (passed as a filename string)
+func ParseKnownHostsLog(givenFilename string) (parsedResults []KnownHostsEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes KnownHostsEntry
+		thisRes, err = thisLogEntryToKnownHostsStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseKnownHostsRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseKnownHostsRecurse(givenDirectory string) (allResults []KnownHostsEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "known_hosts") {
+		thisResult, parseErr := ParseKnownHostsLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllKnownHostsForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed KnownHostsEntry objects
+func GetAllKnownHostsForDay(givenDay string, givenZeekDir ...string) (allRes []KnownHostsEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseKnownHostsRecurse(zeekDir + givenDay + "/")
+	return
+}
@@ -0,0 +1,113 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+// known_services log format described in https://docs.zeek.org/en/master/scripts/policy/protocols/conn/known-services.zeek.html#type-Known::ServicesInfo
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// KnownServicesEntry is a fully parsed known_services.log line.
+type KnownServicesEntry struct {
+	TS time.Time // TS:time - timestamp
+	// ---------
+	Host      string   // host:addr - host address on which the service is running
+	PortNum   Opt[int] // port_num:port - port number on which the service is running
+	PortProto Proto    // port_proto:enum - transport-layer protocol which the service uses
+	Service   []string // service:set[string] - set of protocols that match the service's connection payloads
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (k *KnownServicesEntry) Print() {
+	fmt.Printf("(%s) known service: %s:%s/%s %s\n", k.TS.String(), k.Host, k.PortNum, k.PortProto, k.Service)
+}
+
+func (k *KnownServicesEntry) ShortPrint() {
+	fmt.Printf("[%s] %s:%s %s\n", k.TS, k.Host, k.PortNum, k.Service)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToKnownServicesStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (knownServicesEntry KnownServicesEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			knownServicesEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "host":
+			knownServicesEntry.Host = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "port_num":
+			knownServicesEntry.PortNum, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "port_proto":
+			knownServicesEntry.PortProto = ParseProto(thisField.value)
+		case "service":
+			knownServicesEntry.Service = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseKnownServicesLog will parse through the given single known_services log (passed as a filename string)
+func ParseKnownServicesLog(givenFilename string) (parsedResults []KnownServicesEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes KnownServicesEntry
+		thisRes, err = thisLogEntryToKnownServicesStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseKnownServicesRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseKnownServicesRecurse(givenDirectory string) (allResults []KnownServicesEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "known_services") {
+		thisResult, parseErr := ParseKnownServicesLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllKnownServicesForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed KnownServicesEntry objects
+func GetAllKnownServicesForDay(givenDay string, givenZeekDir ...string) (allRes []KnownServicesEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseKnownServicesRecurse(zeekDir + givenDay + "/")
+	return
+}
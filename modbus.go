@@ -0,0 +1,308 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// modbus log format described in https://docs.zeek.org/en/master/scripts/base/protocols/modbus/main.zeek.html#type-Modbus::Info
+// function and exception codes from https://modbus.org/docs/Modbus_Application_Protocol_V1_1b3.pdf
+
+// ------------------------------
+// ----- Helper Structures ------
+// ------------------------------
+
+// ModbusFunction is a modbus function code.
+type ModbusFunction uint8
+
+const (
+	ModbusReadCoils                  ModbusFunction = 1
+	ModbusReadDiscreteInputs         ModbusFunction = 2
+	ModbusReadHoldingRegisters       ModbusFunction = 3
+	ModbusReadInputRegisters         ModbusFunction = 4
+	ModbusWriteSingleCoil            ModbusFunction = 5
+	ModbusWriteSingleRegister        ModbusFunction = 6
+	ModbusReadExceptionStatus        ModbusFunction = 7
+	ModbusDiagnostics                ModbusFunction = 8
+	ModbusGetCommEventCounter        ModbusFunction = 11
+	ModbusGetCommEventLog            ModbusFunction = 12
+	ModbusWriteMultipleCoils         ModbusFunction = 15
+	ModbusWriteMultipleRegisters     ModbusFunction = 16
+	ModbusReportSlaveId              ModbusFunction = 17
+	ModbusReadFileRecord             ModbusFunction = 20
+	ModbusWriteFileRecord            ModbusFunction = 21
+	ModbusMaskWriteRegister          ModbusFunction = 22
+	ModbusReadWriteMultipleRegisters ModbusFunction = 23
+	ModbusReadFifoQueue              ModbusFunction = 24
+	ModbusEncapInterfaceTransport    ModbusFunction = 43
+)
+
+// modbus function names as zeek logs them
+var modbusFunctionNames = map[ModbusFunction]string{
+	ModbusReadCoils:                  "READ_COILS",
+	ModbusReadDiscreteInputs:         "READ_DISCRETE_INPUTS",
+	ModbusReadHoldingRegisters:       "READ_HOLDING_REGISTERS",
+	ModbusReadInputRegisters:         "READ_INPUT_REGISTERS",
+	ModbusWriteSingleCoil:            "WRITE_SINGLE_COIL",
+	ModbusWriteSingleRegister:        "WRITE_SINGLE_REGISTER",
+	ModbusReadExceptionStatus:        "READ_EXCEPTION_STATUS",
+	ModbusDiagnostics:                "DIAGNOSTICS",
+	ModbusGetCommEventCounter:        "GET_COMM_EVENT_COUNTER",
+	ModbusGetCommEventLog:            "GET_COMM_EVENT_LOG",
+	ModbusWriteMultipleCoils:         "WRITE_MULTIPLE_COILS",
+	ModbusWriteMultipleRegisters:     "WRITE_MULTIPLE_REGISTERS",
+	ModbusReportSlaveId:              "REPORT_SLAVE_ID",
+	ModbusReadFileRecord:             "READ_FILE_RECORD",
+	ModbusWriteFileRecord:            "WRITE_FILE_RECORD",
+	ModbusMaskWriteRegister:          "MASK_WRITE_REGISTER",
+	ModbusReadWriteMultipleRegisters: "READ_WRITE_MULTIPLE_REGISTERS",
+	ModbusReadFifoQueue:              "READ_FIFO_QUEUE",
+	ModbusEncapInterfaceTransport:    "ENCAP_INTERFACE_TRANSPORT",
+}
+
+// String returns the function name the same way zeek logs it.
+func (f ModbusFunction) String() string {
+	if name, ok := modbusFunctionNames[f]; ok {
+		return name
+	}
+	return "unknown-" + strconv.Itoa(int(f))
+}
+
+// IsWrite tells if the function changes state on the outstation.
+func (f ModbusFunction) IsWrite() bool {
+	switch f {
+	case ModbusWriteSingleCoil, ModbusWriteSingleRegister, ModbusWriteMultipleCoils,
+		ModbusWriteMultipleRegisters, ModbusWriteFileRecord, ModbusMaskWriteRegister,
+		ModbusReadWriteMultipleRegisters:
+		return true
+	}
+	return false
+}
+
+// ParseModbusFunction converts a function name as logged by zeek back into its code.  Zeek
+// logs responses to a failed function with an _EXCEPTION suffix which is ignored here.
+func ParseModbusFunction(givenName string) (ModbusFunction, error) {
+	givenName = strings.TrimSuffix(givenName, "_EXCEPTION")
+	if strings.HasPrefix(givenName, "unknown-") {
+		code, err := strconv.ParseUint(strings.TrimPrefix(givenName, "unknown-"), 10, 8)
+		return ModbusFunction(code), err
+	}
+	for code, name := range modbusFunctionNames {
+		if name == givenName {
+			return code, nil
+		}
+	}
+	return 0, errors.New("unknown modbus function: " + givenName)
+}
+
+// ModbusException is a modbus exception code returned by an outstation.
+type ModbusException uint8
+
+const (
+	ModbusIllegalFunction                    ModbusException = 1
+	ModbusIllegalDataAddress                 ModbusException = 2
+	ModbusIllegalDataValue                   ModbusException = 3
+	ModbusSlaveDeviceFailure                 ModbusException = 4
+	ModbusAcknowledge                        ModbusException = 5
+	ModbusSlaveDeviceBusy                    ModbusException = 6
+	ModbusMemoryParityError                  ModbusException = 8
+	ModbusGatewayPathUnavailable             ModbusException = 10
+	ModbusGatewayTargetDeviceFailedToRespond ModbusException = 11
+)
+
+// modbus exception names as zeek logs them and what they mean
+var modbusExceptionNames = map[ModbusException][2]string{
+	ModbusIllegalFunction:                    {"ILLEGAL_FUNCTION", "The function code received is not an allowable action for the outstation."},
+	ModbusIllegalDataAddress:                 {"ILLEGAL_DATA_ADDRESS", "The data address received is not an allowable address for the outstation."},
+	ModbusIllegalDataValue:                   {"ILLEGAL_DATA_VALUE", "A value contained in the query data field is not an allowable value for the outstation."},
+	ModbusSlaveDeviceFailure:                 {"SLAVE_DEVICE_FAILURE", "An unrecoverable error occurred while the outstation was attempting to perform the action."},
+	ModbusAcknowledge:                        {"ACKNOWLEDGE", "The outstation accepted the request but needs a long time to process it."},
+	ModbusSlaveDeviceBusy:                    {"SLAVE_DEVICE_BUSY", "The outstation is busy processing a long-duration command."},
+	ModbusMemoryParityError:                  {"MEMORY_PARITY_ERROR", "The outstation detected a parity error in its memory while reading a file record."},
+	ModbusGatewayPathUnavailable:             {"GATEWAY_PATH_UNAVAILABLE", "The gateway was unable to allocate an internal path to the target."},
+	ModbusGatewayTargetDeviceFailedToRespond: {"GATEWAY_TARGET_DEVICE_FAILED_TO_RESPOND", "No response was obtained from the target device behind the gateway."},
+}
+
+// String returns the exception name the same way zeek logs it.
+func (e ModbusException) String() string {
+	if names, ok := modbusExceptionNames[e]; ok {
+		return names[0]
+	}
+	return "unknown-" + strconv.Itoa(int(e))
+}
+
+// Description returns a human readable explanation of the exception.
+func (e ModbusException) Description() string {
+	if names, ok := modbusExceptionNames[e]; ok {
+		return names[1]
+	}
+	return "Unknown exception code."
+}
+
+// ParseModbusException converts an exception name as logged by zeek back into its code.
+func ParseModbusException(givenName string) (ModbusException, error) {
+	if strings.HasPrefix(givenName, "unknown-") {
+		code, err := strconv.ParseUint(strings.TrimPrefix(givenName, "unknown-"), 10, 8)
+		return ModbusException(code), err
+	}
+	for code, names := range modbusExceptionNames {
+		if names[0] == givenName {
+			return code, nil
+		}
+	}
+	return 0, errors.New("unknown modbus exception: " + givenName)
+}
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// ModbusEntry is a fully parsed modbus.log line.
+type ModbusEntry struct {
+	TS      time.Time // TS:time - timestamp
+	Uid     string    // Uid:string - unique id
+	IdOrigH string    // id_orig_h:addr - senders address
+	IdOrigP int       // id_orig_p:addr - senders port
+	IdRespH string    // id_resp_h:port - responders address
+	IdRespP int       // id_resp_p:port - responders port
+	// ---------
+	Tid           Opt[int]        // tid:count - modbus transaction id
+	Unit          Opt[int]        // unit:count - the terminal unit identifier of the message
+	Func          ModbusFunction  // func:string - the function code of the message
+	FuncName      string          // func:string - the name of the function message as logged
+	PduType       string          // pdu_type:string - whether this PDU was a response ("RESP") or request ("REQ")
+	Exception     ModbusException // exception:string - the exception if the response was a failure
+	ExceptionName string          // exception:string - the name of the exception as logged
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (m *ModbusEntry) Print() {
+	fmt.Printf("(%s) master {%s:%d} talks to outstation {%s:%d}:\n",
+		m.TS.String(), m.IdOrigH, m.IdOrigP, m.IdRespH, m.IdRespP)
+	fmt.Printf("\t%s %s %s\n", m.PduType, m.FuncName, m.ExceptionName)
+}
+
+func (m *ModbusEntry) ShortPrint() {
+	fmt.Printf("[%s] %s -> %s %s %s\n", m.TS, m.IdOrigH, m.IdRespH, m.FuncName, m.ExceptionName)
+}
+
+// ------------------------------
+// ----    Entry Helpers   ------
+// ------------------------------
+
+// IsException tells if the outstation responded with an exception.
+func (m *ModbusEntry) IsException() bool {
+	return len(m.ExceptionName) > 0
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToModbusStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (modbusEntry ModbusEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			modbusEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "uid":
+			modbusEntry.Uid = thisField.value
+		case "id.orig_h":
+			modbusEntry.IdOrigH = thisField.value
+		case "id.orig_p":
+			modbusEntry.IdOrigP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id.resp_h":
+			modbusEntry.IdRespH = thisField.value
+		case "id.resp_p":
+			modbusEntry.IdRespP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "tid":
+			modbusEntry.Tid, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "unit":
+			modbusEntry.Unit, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "func":
+			modbusEntry.FuncName = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+			if len(modbusEntry.FuncName) > 0 {
+				// unrecognised names are still kept in FuncName
+				modbusEntry.Func, _ = ParseModbusFunction(modbusEntry.FuncName)
+			}
+		case "pdu_type":
+			modbusEntry.PduType = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "exception":
+			modbusEntry.ExceptionName = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+			if len(modbusEntry.ExceptionName) > 0 {
+				modbusEntry.Exception, _ = ParseModbusException(modbusEntry.ExceptionName)
+			}
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseModbusLog will parse through the given single modbus log (passed as a filename string)
+func ParseModbusLog(givenFilename string) (parsedResults []ModbusEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes ModbusEntry
+		thisRes, err = thisLogEntryToModbusStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseModbusRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseModbusRecurse(givenDirectory string) (allResults []ModbusEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "modbus") {
+		thisResult, parseErr := ParseModbusLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllModbusForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed ModbusEntry objects
+func GetAllModbusForDay(givenDay string, givenZeekDir ...string) (allRes []ModbusEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseModbusRecurse(zeekDir + givenDay + "/")
+	return
+}
@@ -0,0 +1,41 @@
+package zeekparse
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThisLogEntryToModbusStruct(t *testing.T) {
+	log.SetFormatter(&log.TextFormatter{ForceColors: true})
+	log.SetLevel(log.InfoLevel)
+
+	// compressed case
+	compressedResults, header, compErr := parseZeekLog("test_input/simple_modbus.log.gz")
+	for _, thisResult := range compressedResults {
+		_, parseErr := thisLogEntryToModbusStruct(thisResult, header)
+		assert.NoError(t, parseErr)
+	}
+	assert.NoError(t, compErr)
+}
+
+func TestParseModbusLog(t *testing.T) {
+	allModbus, err := ParseModbusLog("test_input/simple_modbus.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, len(allModbus))
+
+	assert.Equal(t, ModbusReadHoldingRegisters, allModbus[0].Func)
+	assert.False(t, allModbus[0].IsException())
+
+	assert.Equal(t, ModbusWriteSingleRegister, allModbus[3].Func)
+	assert.True(t, allModbus[3].Func.IsWrite())
+	assert.True(t, allModbus[3].IsException())
+	assert.Equal(t, ModbusIllegalDataAddress, allModbus[3].Exception)
+	assert.Equal(t, "ILLEGAL_DATA_ADDRESS", allModbus[3].Exception.String())
+
+	assert.Equal(t, ModbusFunction(90), allModbus[4].Func)
+	assert.Equal(t, "unknown-90", allModbus[4].Func.String())
+
+	_, err = ParseModbusFunction("NOT_A_FUNCTION")
+	assert.Error(t, err)
+}
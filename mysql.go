@@ -0,0 +1,140 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"time"
+)
+
+// mysql log format described in https://docs.zeek.org/en/master/scripts/base/protocols/mysql/main.zeek.html#type-MySQL::Info
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// MysqlEntry is a fully parsed mysql.log line.
+type MysqlEntry struct {
+	TS      time.Time // TS:time - timestamp
+	Uid     string    // Uid:string - unique id
+	IdOrigH string    // id_orig_h:addr - senders address
+	IdOrigP int       // id_orig_p:addr - senders port
+	IdRespH string    // id_resp_h:port - responders address
+	IdRespP int       // id_resp_p:port - responders port
+	// ---------
+	Cmd      string   // cmd:string - the command that was issued
+	Arg      string   // arg:string - the argument issued to the command
+	Success  bool     // success:bool - did the server tell us that the command succeeded
+	Rows     Opt[int] // rows:count - the number of affected rows if any
+	Response string   // response:string - server message if any
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (m *MysqlEntry) Print() {
+	fmt.Printf("(%s) client {%s:%d} talks to {%s:%d}:\n",
+		m.TS.String(), m.IdOrigH, m.IdOrigP, m.IdRespH, m.IdRespP)
+	fmt.Printf("\t%s %s success:%t rows:%s %s\n", m.Cmd, m.Arg, m.Success, m.Rows, m.Response)
+}
+
+func (m *MysqlEntry) ShortPrint() {
+	fmt.Printf("[%s] %s %s %s\n", m.TS, m.IdOrigH, m.Cmd, m.Arg)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToMysqlStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (mysqlEntry MysqlEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			mysqlEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "uid":
+			mysqlEntry.Uid = thisField.value
+		case "id.orig_h":
+			mysqlEntry.IdOrigH = thisField.value
+		case "id.orig_p":
+			mysqlEntry.IdOrigP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id.resp_h":
+			mysqlEntry.IdRespH = thisField.value
+		case "id.resp_p":
+			mysqlEntry.IdRespP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "cmd":
+			mysqlEntry.Cmd = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "arg":
+			mysqlEntry.Arg = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "success":
+			mysqlEntry.Success = thisField.value == "T"
+		case "rows":
+			mysqlEntry.Rows, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "response":
+			mysqlEntry.Response = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseMysqlLog will parse through the given single mysql log (passed as a filename string)
+func ParseMysqlLog(givenFilename string) (parsedResults []MysqlEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes MysqlEntry
+		thisRes, err = thisLogEntryToMysqlStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseMysqlRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseMysqlRecurse(givenDirectory string) (allResults []MysqlEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "mysql") {
+		thisResult, parseErr := ParseMysqlLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllMysqlForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed MysqlEntry objects
+func GetAllMysqlForDay(givenDay string, givenZeekDir ...string) (allRes []MysqlEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseMysqlRecurse(zeekDir + givenDay + "/")
+	return
+}
@@ -0,0 +1,29 @@
+package zeekparse
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThisLogEntryToMysqlStruct(t *testing.T) {
+	log.SetFormatter(&log.TextFormatter{ForceColors: true})
+	log.SetLevel(log.InfoLevel)
+
+	// compressed case
+	compressedResults, header, compErr := parseZeekLog("test_input/simple_mysql.log.gz")
+	for _, thisResult := range compressedResults {
+		_, parseErr := thisLogEntryToMysqlStruct(thisResult, header)
+		assert.NoError(t, parseErr)
+	}
+	assert.NoError(t, compErr)
+
+	allMysql, err := ParseMysqlLog("test_input/simple_mysql.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(allMysql))
+	assert.Equal(t, "SELECT * FROM users", allMysql[1].Arg)
+	assert.Equal(t, Some(12), allMysql[1].Rows)
+	assert.False(t, allMysql[2].Success)
+	assert.False(t, allMysql[2].Rows.IsSet())
+	assert.Equal(t, "Unknown table 'nope'", allMysql[2].Response)
+}
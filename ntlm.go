@@ -0,0 +1,143 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"time"
+)
+
+// ntlm log format described in https://docs.zeek.org/en/master/scripts/base/protocols/ntlm/main.zeek.html#type-NTLM::Info
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// NtlmEntry is a fully parsed ntlm.log line.
+type NtlmEntry struct {
+	TS      time.Time // TS:time - timestamp
+	Uid     string    // Uid:string - unique id
+	IdOrigH string    // id_orig_h:addr - senders address
+	IdOrigP int       // id_orig_p:addr - senders port
+	IdRespH string    // id_resp_h:port - responders address
+	IdRespP int       // id_resp_p:port - responders port
+	// ---------
+	Username              string // username:string - username given by the client
+	Hostname              string // hostname:string - hostname given by the client
+	DomainName            string // domainname:string - domainname given by the client
+	ServerNbComputerName  string // server_nb_computer_name:string - NetBIOS name given by the server in a CHALLENGE
+	ServerDnsComputerName string // server_dns_computer_name:string - DNS name given by the server in a CHALLENGE
+	ServerTreeName        string // server_tree_name:string - tree name given by the server in a CHALLENGE
+	Success               bool   // success:bool - indicate whether or not the authentication was successful
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (n *NtlmEntry) Print() {
+	fmt.Printf("(%s) client {%s:%d} talks to {%s:%d}:\n",
+		n.TS.String(), n.IdOrigH, n.IdOrigP, n.IdRespH, n.IdRespP)
+	fmt.Printf("\t%s\\%s from %s success:%t\n", n.DomainName, n.Username, n.Hostname, n.Success)
+}
+
+func (n *NtlmEntry) ShortPrint() {
+	fmt.Printf("[%s] %s %s\\%s -> %s\n", n.TS, n.IdOrigH, n.DomainName, n.Username, n.IdRespH)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToNtlmStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (ntlmEntry NtlmEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			ntlmEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "uid":
+			ntlmEntry.Uid = thisField.value
+		case "id.orig_h":
+			ntlmEntry.IdOrigH = thisField.value
+		case "id.orig_p":
+			ntlmEntry.IdOrigP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id.resp_h":
+			ntlmEntry.IdRespH = thisField.value
+		case "id.resp_p":
+			ntlmEntry.IdRespP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "username":
+			ntlmEntry.Username = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "hostname":
+			ntlmEntry.Hostname = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "domainname":
+			ntlmEntry.DomainName = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "server_nb_computer_name":
+			ntlmEntry.ServerNbComputerName = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "server_dns_computer_name":
+			ntlmEntry.ServerDnsComputerName = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "server_tree_name":
+			ntlmEntry.ServerTreeName = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "success":
+			ntlmEntry.Success = thisField.value == "T"
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseNtlmLog will parse through the given single ntlm log (passed as a filename string)
+func ParseNtlmLog(givenFilename string) (parsedResults []NtlmEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes NtlmEntry
+		thisRes, err = thisLogEntryToNtlmStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseNtlmRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseNtlmRecurse(givenDirectory string) (allResults []NtlmEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "ntlm") {
+		thisResult, parseErr := ParseNtlmLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllNtlmForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed NtlmEntry objects
+func GetAllNtlmForDay(givenDay string, givenZeekDir ...string) (allRes []NtlmEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseNtlmRecurse(zeekDir + givenDay + "/")
+	return
+}
@@ -0,0 +1,205 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"time"
+)
+
+// ntp log format described in https://docs.zeek.org/en/master/scripts/base/protocols/ntp/main.zeek.html#type-NTP::Info
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// NtpEntry is a fully parsed ntp.log line.
+type NtpEntry struct {
+	TS      time.Time // TS:time - timestamp
+	Uid     string    // Uid:string - unique id
+	IdOrigH string    // id_orig_h:addr - senders address
+	IdOrigP int       // id_orig_p:addr - senders port
+	IdRespH string    // id_resp_h:port - responders address
+	IdRespP int       // id_resp_p:port - responders port
+	// ---------
+	Version   Opt[int]     // version:count - the NTP version number (1, 2, 3, 4)
+	Mode      Opt[int]     // mode:count - the NTP mode being used
+	Stratum   Opt[int]     // stratum:count - the stratum (primary server, secondary server, etc.)
+	Poll      Opt[float64] // poll:interval - the maximum interval between successive messages
+	Precision Opt[float64] // precision:interval - the precision of the system clock
+	RootDelay Opt[float64] // root_delay:interval - total round-trip delay to the reference clock
+	RootDisp  Opt[float64] // root_disp:interval - total dispersion to the reference clock
+	RefId     string       // ref_id:string - for stratum 0, 4 character string used for debugging; for stratum 1, ID assigned to the reference clock by IANA; above stratum 1 the server address
+	RefTime   time.Time    // ref_time:time - time when the system clock was last set or correct
+	OrgTime   time.Time    // org_time:time - time at the client when the request departed for the NTP server
+	RecTime   time.Time    // rec_time:time - time at the server when the request arrived from the NTP client
+	XmtTime   time.Time    // xmt_time:time - time at the server when the response departed for the NTP client
+	NumExts   Opt[int]     // num_exts:count - number of extension fields (which are not currently parsed)
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (n *NtpEntry) Print() {
+	fmt.Printf("(%s) client {%s:%d} talks to {%s:%d}:\n",
+		n.TS.String(), n.IdOrigH, n.IdOrigP, n.IdRespH, n.IdRespP)
+	fmt.Printf("\tv%s mode:%s stratum:%s ref:%s xmt:%s\n", n.Version, n.Mode, n.Stratum, n.RefId, n.XmtTime)
+}
+
+func (n *NtpEntry) ShortPrint() {
+	fmt.Printf("[%s] %s -> %s stratum:%s\n", n.TS, n.IdOrigH, n.IdRespH, n.Stratum)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToNtpStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (ntpEntry NtpEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			ntpEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "uid":
+			ntpEntry.Uid = thisField.value
+		case "id.orig_h":
+			ntpEntry.IdOrigH = thisField.value
+		case "id.orig_p":
+			ntpEntry.IdOrigP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id.resp_h":
+			ntpEntry.IdRespH = thisField.value
+		case "id.resp_p":
+			ntpEntry.IdRespP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "version":
+			ntpEntry.Version, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "mode":
+			ntpEntry.Mode, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "stratum":
+			ntpEntry.Stratum, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "poll":
+			ntpEntry.Poll, err = optFloat(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "precision":
+			ntpEntry.Precision, err = optFloat(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "root_delay":
+			ntpEntry.RootDelay, err = optFloat(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "root_disp":
+			ntpEntry.RootDisp, err = optFloat(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "ref_id":
+			ntpEntry.RefId = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "ref_time":
+			if thisField.value != givenLogOpts.unsetField {
+				ntpEntry.RefTime, err = UnixStrToTime(thisField.value)
+				if err != nil {
+					return
+				}
+			}
+		case "org_time":
+			if thisField.value != givenLogOpts.unsetField {
+				ntpEntry.OrgTime, err = UnixStrToTime(thisField.value)
+				if err != nil {
+					return
+				}
+			}
+		case "rec_time":
+			if thisField.value != givenLogOpts.unsetField {
+				ntpEntry.RecTime, err = UnixStrToTime(thisField.value)
+				if err != nil {
+					return
+				}
+			}
+		case "xmt_time":
+			if thisField.value != givenLogOpts.unsetField {
+				ntpEntry.XmtTime, err = UnixStrToTime(thisField.value)
+				if err != nil {
+					return
+				}
+			}
+		case "num_exts":
+			ntpEntry.NumExts, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseNtpLog will parse through the given single ntp log (passed as a filename string)
+func ParseNtpLog(givenFilename string) (parsedResults []NtpEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes NtpEntry
+		thisRes, err = thisLogEntryToNtpStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseNtpRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseNtpRecurse(givenDirectory string) (allResults []NtpEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "ntp") {
+		thisResult, parseErr := ParseNtpLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllNtpForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed NtpEntry objects
+func GetAllNtpForDay(givenDay string, givenZeekDir ...string) (allRes []NtpEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseNtpRecurse(zeekDir + givenDay + "/")
+	return
+}
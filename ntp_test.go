@@ -0,0 +1,30 @@
+package zeekparse
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThisLogEntryToNtpStruct(t *testing.T) {
+	log.SetFormatter(&log.TextFormatter{ForceColors: true})
+	log.SetLevel(log.InfoLevel)
+
+	// compressed case
+	compressedResults, header, compErr := parseZeekLog("test_input/simple_ntp.log.gz")
+	for _, thisResult := range compressedResults {
+		_, parseErr := thisLogEntryToNtpStruct(thisResult, header)
+		assert.NoError(t, parseErr)
+	}
+	assert.NoError(t, compErr)
+
+	allNtp, err := ParseNtpLog("test_input/simple_ntp.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(allNtp))
+	assert.Equal(t, Some(3), allNtp[0].Mode)
+	assert.Equal(t, Some(4), allNtp[1].Mode)
+	assert.Equal(t, Some(2), allNtp[1].Stratum)
+	assert.Equal(t, "198.51.100.1", allNtp[1].RefId)
+	assert.Equal(t, Some(64.0), allNtp[1].Poll)
+	assert.Equal(t, int64(1621332400), allNtp[1].XmtTime.Unix())
+}
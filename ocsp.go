@@ -0,0 +1,144 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+// ocsp log format described in https://docs.zeek.org/en/master/scripts/policy/files/x509/log-ocsp.zeek.html#type-OCSP::Info
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// OcspEntry is a fully parsed ocsp.log line.
+type OcspEntry struct {
+	TS time.Time // TS:time - timestamp
+	// ---------
+	Id             string    // id:string - file id of the OCSP reply
+	HashAlgorithm  string    // hashAlgorithm:string - hash algorithm used to generate issuerNameHash and issuerKeyHash
+	IssuerNameHash string    // issuerNameHash:string - hash of the issuer's distingueshed name
+	IssuerKeyHash  string    // issuerKeyHash:string - hash of the issuer's public key
+	SerialNumber   string    // serialNumber:string - serial number of the affected certificate
+	CertStatus     string    // certStatus:string - status of the affected certificate (good, revoked, unknown)
+	RevokeTime     time.Time // revoketime:time - time at which the certificate was revoked
+	RevokeReason   string    // revokereason:string - reason for which the certificate was revoked
+	ThisUpdate     time.Time // thisUpdate:time - the time at which the status being shown is known to have been correct
+	NextUpdate     time.Time // nextUpdate:time - the latest time at which new information about the status of the certificate will be available
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (o *OcspEntry) Print() {
+	fmt.Printf("(%s) %s serial:%s status:%s revoked:%s %s\n", o.TS.String(), o.Id, o.SerialNumber,
+		o.CertStatus, o.RevokeTime.String(), o.RevokeReason)
+}
+
+func (o *OcspEntry) ShortPrint() {
+	fmt.Printf("[%s] %s %s\n", o.TS, o.SerialNumber, o.CertStatus)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToOcspStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (ocspEntry OcspEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			ocspEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id":
+			ocspEntry.Id = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "hashAlgorithm":
+			ocspEntry.HashAlgorithm = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "issuerNameHash":
+			ocspEntry.IssuerNameHash = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "issuerKeyHash":
+			ocspEntry.IssuerKeyHash = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "serialNumber":
+			ocspEntry.SerialNumber = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "certStatus":
+			ocspEntry.CertStatus = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "revoketime":
+			if thisField.value != givenLogOpts.unsetField {
+				ocspEntry.RevokeTime, err = UnixStrToTime(thisField.value)
+				if err != nil {
+					return
+				}
+			}
+		case "revokereason":
+			ocspEntry.RevokeReason = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "thisUpdate":
+			if thisField.value != givenLogOpts.unsetField {
+				ocspEntry.ThisUpdate, err = UnixStrToTime(thisField.value)
+				if err != nil {
+					return
+				}
+			}
+		case "nextUpdate":
+			if thisField.value != givenLogOpts.unsetField {
+				ocspEntry.NextUpdate, err = UnixStrToTime(thisField.value)
+				if err != nil {
+					return
+				}
+			}
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseOcspLog will parse through the given single ocsp log (passed as a filename string)
+func ParseOcspLog(givenFilename string) (parsedResults []OcspEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes OcspEntry
+		thisRes, err = thisLogEntryToOcspStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseOcspRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseOcspRecurse(givenDirectory string) (allResults []OcspEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "ocsp") {
+		thisResult, parseErr := ParseOcspLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllOcspForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed OcspEntry objects
+func GetAllOcspForDay(givenDay string, givenZeekDir ...string) (allRes []OcspEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseOcspRecurse(zeekDir + givenDay + "/")
+	return
+}
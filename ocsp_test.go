@@ -0,0 +1,30 @@
+package zeekparse
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThisLogEntryToOcspStruct(t *testing.T) {
+	log.SetFormatter(&log.TextFormatter{ForceColors: true})
+	log.SetLevel(log.InfoLevel)
+
+	// compressed case
+	compressedResults, header, compErr := parseZeekLog("test_input/simple_ocsp.log.gz")
+	for _, thisResult := range compressedResults {
+		_, parseErr := thisLogEntryToOcspStruct(thisResult, header)
+		assert.NoError(t, parseErr)
+	}
+	assert.NoError(t, compErr)
+
+	allOcsp, err := ParseOcspLog("test_input/simple_ocsp.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(allOcsp))
+	assert.Equal(t, "good", allOcsp[0].CertStatus)
+	assert.True(t, allOcsp[0].RevokeTime.IsZero())
+	assert.Equal(t, "revoked", allOcsp[1].CertStatus)
+	assert.Equal(t, "keyCompromise", allOcsp[1].RevokeReason)
+	assert.Equal(t, int64(1620000000), allOcsp[1].RevokeTime.Unix())
+	assert.True(t, allOcsp[1].NextUpdate.IsZero())
+}
@@ -0,0 +1,87 @@
+package zeekparse
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Opt is a value from a zeek log that may be unset (logged as "-").  The zero Opt is unset, so
+// fields zeek did not log at all read the same as fields it logged as unset.
+type Opt[T any] struct {
+	value T
+	set   bool
+}
+
+// Some returns an Opt holding the given value.
+func Some[T any](givenValue T) Opt[T] {
+	return Opt[T]{value: givenValue, set: true}
+}
+
+// IsSet tells if a value was logged.
+func (o Opt[T]) IsSet() bool {
+	return o.set
+}
+
+// Get returns the value and whether it was set, in the style of a map lookup.
+func (o Opt[T]) Get() (T, bool) {
+	return o.value, o.set
+}
+
+// Value returns the value, or the zero value of T if unset.
+func (o Opt[T]) Value() T {
+	return o.value
+}
+
+// Or returns the value, or the given default if unset.
+func (o Opt[T]) Or(givenDefault T) T {
+	if !o.set {
+		return givenDefault
+	}
+	return o.value
+}
+
+// String returns the value as printed by fmt, or "-" if unset to match zeek's own logs.
+func (o Opt[T]) String() string {
+	if !o.set {
+		return "-"
+	}
+	return fmt.Sprint(o.value)
+}
+
+// optInt is a convenience function for parsers that will convert the given value to an
+// Opt[int], leaving it unset if it matches the unset char given.
+func optInt(givenValue string, givenUnset string) (Opt[int], error) {
+	if givenValue == givenUnset {
+		return Opt[int]{}, nil
+	}
+	parsed, err := strconv.Atoi(givenValue)
+	if err != nil {
+		return Opt[int]{}, err
+	}
+	return Some(parsed), nil
+}
+
+// optFloat is a convenience function for parsers that will convert the given value to an
+// Opt[float64], leaving it unset if it matches the unset char given.
+func optFloat(givenValue string, givenUnset string) (Opt[float64], error) {
+	if givenValue == givenUnset {
+		return Opt[float64]{}, nil
+	}
+	parsed, err := strconv.ParseFloat(givenValue, 64)
+	if err != nil {
+		return Opt[float64]{}, err
+	}
+	return Some(parsed), nil
+}
+
+// optBool converts a zeek bool value (T or F) to an Opt[bool], leaving it unset for
+// anything else.
+func optBool(givenValue string) Opt[bool] {
+	switch givenValue {
+	case "T":
+		return Some(true)
+	case "F":
+		return Some(false)
+	}
+	return Opt[bool]{}
+}
@@ -0,0 +1,45 @@
+package zeekparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOpt(t *testing.T) {
+	var unset Opt[int]
+	assert.False(t, unset.IsSet())
+	assert.Equal(t, 0, unset.Value())
+	assert.Equal(t, 7, unset.Or(7))
+	assert.Equal(t, "-", unset.String())
+
+	set := Some(0)
+	assert.True(t, set.IsSet())
+	value, ok := set.Get()
+	assert.True(t, ok)
+	assert.Equal(t, 0, value)
+	assert.Equal(t, 0, set.Or(7))
+	assert.Equal(t, "0", set.String())
+	assert.NotEqual(t, unset, set)
+}
+
+func TestOptParse(t *testing.T) {
+	i, err := optInt("42", "-")
+	assert.NoError(t, err)
+	assert.Equal(t, Some(42), i)
+	i, err = optInt("-", "-")
+	assert.NoError(t, err)
+	assert.False(t, i.IsSet())
+	_, err = optInt("nope", "-")
+	assert.Error(t, err)
+
+	f, err := optFloat("0.5", "-")
+	assert.NoError(t, err)
+	assert.Equal(t, Some(0.5), f)
+	f, err = optFloat("-", "-")
+	assert.NoError(t, err)
+	assert.False(t, f.IsSet())
+
+	assert.Equal(t, Some(true), optBool("T"))
+	assert.Equal(t, Some(false), optBool("F"))
+	assert.False(t, optBool("-").IsSet())
+}
@@ -0,0 +1,113 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+// packet_filter log format described in https://docs.zeek.org/en/master/scripts/base/frameworks/packet-filter/main.zeek.html#type-PacketFilter::Info
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// PacketFilterEntry is a fully parsed packet_filter.log line.
+type PacketFilterEntry struct {
+	TS time.Time // TS:time - timestamp
+	// ---------
+	Node          string // node:string - node this filter was installed on
+	Filter        string // filter:string - the packet filter that is being set
+	Init          bool   // init:bool - indicate if this is the filter set during zeek initialization
+	Success       bool   // success:bool - indicate if the filter was applied successfully
+	FailureReason string // failure_reason:string - reason the filter failed to apply if it did
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (p *PacketFilterEntry) Print() {
+	fmt.Printf("(%s) %s filter \"%s\" init:%t success:%t %s\n", p.TS.String(), p.Node, p.Filter, p.Init, p.Success, p.FailureReason)
+}
+
+func (p *PacketFilterEntry) ShortPrint() {
+	fmt.Printf("[%s] %s %s success:%t\n", p.TS, p.Node, p.Filter, p.Success)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToPacketFilterStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (packetFilterEntry PacketFilterEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			packetFilterEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "node":
+			packetFilterEntry.Node = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "filter":
+			packetFilterEntry.Filter = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "init":
+			packetFilterEntry.Init = thisField.value == "T"
+		case "success":
+			packetFilterEntry.Success = thisField.value == "T"
+		case "failure_reason":
+			packetFilterEntry.FailureReason = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParsePacketFilterLog will parse through the given single packet_filter log (passed as a filename string)
+func ParsePacketFilterLog(givenFilename string) (parsedResults []PacketFilterEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes PacketFilterEntry
+		thisRes, err = thisLogEntryToPacketFilterStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParsePacketFilterRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParsePacketFilterRecurse(givenDirectory string) (allResults []PacketFilterEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "packet_filter") {
+		thisResult, parseErr := ParsePacketFilterLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllPacketFilterForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed PacketFilterEntry objects
+func GetAllPacketFilterForDay(givenDay string, givenZeekDir ...string) (allRes []PacketFilterEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParsePacketFilterRecurse(zeekDir + givenDay + "/")
+	return
+}
@@ -0,0 +1,152 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+// pe log format described in https://docs.zeek.org/en/master/scripts/base/files/pe/main.zeek.html#type-PE::Info
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// PeEntry is a fully parsed pe.log line.
+type PeEntry struct {
+	TS time.Time // TS:time - timestamp
+	// ---------
+	Id                string    // id:string - file id of this portable executable file
+	Machine           string    // machine:string - the target machine that the file was compiled for
+	CompileTS         time.Time // compile_ts:time - the time that the file was created at
+	OS                string    // os:string - the required operating system
+	Subsystem         string    // subsystem:string - the subsystem that is required to run this file
+	IsExe             bool      // is_exe:bool - is the file an executable, or just an object file
+	Is64Bit           bool      // is_64bit:bool - is the file a 64-bit executable
+	UsesAslr          bool      // uses_aslr:bool - does the file support Address Space Layout Randomization
+	UsesDep           bool      // uses_dep:bool - does the file support Data Execution Prevention
+	UsesCodeIntegrity bool      // uses_code_integrity:bool - does the file enforce code integrity checks
+	UsesSeh           bool      // uses_seh:bool - does the file use structured exception handing
+	HasImportTable    bool      // has_import_table:bool - does the file have an import table
+	HasExportTable    bool      // has_export_table:bool - does the file have an export table
+	HasCertTable      bool      // has_cert_table:bool - does the file have an attribute certificate table
+	HasDebugData      bool      // has_debug_data:bool - does the file have a debug table
+	SectionNames      []string  // section_names:vector[string] - the names of the sections, in order
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (p *PeEntry) Print() {
+	fmt.Printf("(%s) %s %s %s compiled:%s exe:%t 64bit:%t sections:%s\n", p.TS.String(), p.Id, p.Machine, p.OS,
+		p.CompileTS.String(), p.IsExe, p.Is64Bit, p.SectionNames)
+}
+
+func (p *PeEntry) ShortPrint() {
+	fmt.Printf("[%s] %s %s %s\n", p.TS, p.Id, p.Machine, p.OS)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToPeStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (peEntry PeEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			peEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id":
+			peEntry.Id = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "machine":
+			peEntry.Machine = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "compile_ts":
+			if thisField.value != givenLogOpts.unsetField {
+				peEntry.CompileTS, err = UnixStrToTime(thisField.value)
+				if err != nil {
+					return
+				}
+			}
+		case "os":
+			peEntry.OS = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "subsystem":
+			peEntry.Subsystem = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "is_exe":
+			peEntry.IsExe = thisField.value == "T"
+		case "is_64bit":
+			peEntry.Is64Bit = thisField.value == "T"
+		case "uses_aslr":
+			peEntry.UsesAslr = thisField.value == "T"
+		case "uses_dep":
+			peEntry.UsesDep = thisField.value == "T"
+		case "uses_code_integrity":
+			peEntry.UsesCodeIntegrity = thisField.value == "T"
+		case "uses_seh":
+			peEntry.UsesSeh = thisField.value == "T"
+		case "has_import_table":
+			peEntry.HasImportTable = thisField.value == "T"
+		case "has_export_table":
+			peEntry.HasExportTable = thisField.value == "T"
+		case "has_cert_table":
+			peEntry.HasCertTable = thisField.value == "T"
+		case "has_debug_data":
+			peEntry.HasDebugData = thisField.value == "T"
+		case "section_names":
+			peEntry.SectionNames = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParsePeLog will parse through the given single pe log (passed as a filename string)
+func ParsePeLog(givenFilename string) (parsedResults []PeEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes PeEntry
+		thisRes, err = thisLogEntryToPeStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParsePeRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParsePeRecurse(givenDirectory string) (allResults []PeEntry, err error) {
+	for thisFile := range PathRecursePrefix(givenDirectory, "pe.") {
+		thisResult, parseErr := ParsePeLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllPeForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed PeEntry objects
+func GetAllPeForDay(givenDay string, givenZeekDir ...string) (allRes []PeEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParsePeRecurse(zeekDir + givenDay + "/")
+	return
+}
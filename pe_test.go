@@ -0,0 +1,32 @@
+package zeekparse
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThisLogEntryToPeStruct(t *testing.T) {
+	log.SetFormatter(&log.TextFormatter{ForceColors: true})
+	log.SetLevel(log.InfoLevel)
+
+	// compressed case
+	compressedResults, header, compErr := parseZeekLog("test_input/simple_pe.log.gz")
+	for _, thisResult := range compressedResults {
+		_, parseErr := thisLogEntryToPeStruct(thisResult, header)
+		assert.NoError(t, parseErr)
+	}
+	assert.NoError(t, compErr)
+
+	allPe, err := ParsePeLog("test_input/simple_pe.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(allPe))
+	assert.Equal(t, "AMD64", allPe[0].Machine)
+	assert.True(t, allPe[0].Is64Bit)
+	assert.True(t, allPe[0].UsesAslr)
+	assert.Equal(t, int64(1600000000), allPe[0].CompileTS.Unix())
+	assert.Equal(t, []string{".text", ".rdata", ".data", ".pdata", ".rsrc", ".reloc"}, allPe[0].SectionNames)
+	assert.False(t, allPe[1].IsExe)
+	assert.True(t, allPe[1].CompileTS.IsZero())
+	assert.Nil(t, allPe[1].SectionNames)
+}
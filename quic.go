@@ -0,0 +1,154 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// quic log format described in https://docs.zeek.org/en/master/scripts/base/protocols/quic/main.zeek.html#type-QUIC::Info
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// QuicEntry is a fully parsed quic.log line.
+type QuicEntry struct {
+	TS      time.Time // TS:time - timestamp
+	Uid     string    // Uid:string - unique id
+	IdOrigH string    // id_orig_h:addr - senders address
+	IdOrigP int       // id_orig_p:addr - senders port
+	IdRespH string    // id_resp_h:port - responders address
+	IdRespP int       // id_resp_p:port - responders port
+	// ---------
+	Version           string // version:string - QUIC version as found in the first INITIAL packet from the client
+	ClientInitialDcid string // client_initial_dcid:string - first Destination Connection ID used by the client
+	ClientScid        string // client_scid:string - client's Source Connection ID from the first INITIAL packet
+	ServerScid        string // server_scid:string - server chosen Connection ID usually from the server's first INITIAL packet
+	ServerName        string // server_name:string - server name extracted from the SNI extension in the client's first INITIAL packet
+	ClientProtocol    string // client_protocol:string - first protocol extracted from the ALPN extension in the client's first INITIAL packet
+	History           string // history:string - QUIC history, uppercase letters for the client and lowercase for the server
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (q *QuicEntry) Print() {
+	fmt.Printf("(%s) client {%s:%d} talks to {%s:%d}:\n",
+		q.TS.String(), q.IdOrigH, q.IdOrigP, q.IdRespH, q.IdRespP)
+	fmt.Printf("\tV:%s SNI:%s ALPN:%s HISTORY:%s\n", q.Version, q.ServerName, q.ClientProtocol, q.History)
+}
+
+func (q *QuicEntry) ShortPrint() {
+	fmt.Printf("[%s] %s -> %s:%d %s\n", q.TS, q.IdOrigH, q.IdRespH, q.IdRespP, q.ServerName)
+}
+
+// ------------------------------
+// ----    Entry Helpers   ------
+// ------------------------------
+
+// IsHTTP3 returns true if the client offered HTTP/3 (h3 or one of the h3-NN drafts) as its
+// application protocol.
+func (q *QuicEntry) IsHTTP3() bool {
+	return q.ClientProtocol == "h3" || strings.HasPrefix(q.ClientProtocol, "h3-")
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToQuicStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (quicEntry QuicEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			quicEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "uid":
+			quicEntry.Uid = thisField.value
+		case "id.orig_h":
+			quicEntry.IdOrigH = thisField.value
+		case "id.orig_p":
+			quicEntry.IdOrigP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id.resp_h":
+			quicEntry.IdRespH = thisField.value
+		case "id.resp_p":
+			quicEntry.IdRespP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "version":
+			quicEntry.Version = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "client_initial_dcid":
+			quicEntry.ClientInitialDcid = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "client_scid":
+			quicEntry.ClientScid = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "server_scid":
+			quicEntry.ServerScid = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "server_name":
+			quicEntry.ServerName = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "client_protocol":
+			quicEntry.ClientProtocol = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "history":
+			quicEntry.History = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseQuicLog will parse through the given single quic log (passed as a filename string)
+func ParseQuicLog(givenFilename string) (parsedResults []QuicEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes QuicEntry
+		thisRes, err = thisLogEntryToQuicStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseQuicRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseQuicRecurse(givenDirectory string) (allResults []QuicEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "quic") {
+		thisResult, parseErr := ParseQuicLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllQuicForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed QuicEntry objects
+func GetAllQuicForDay(givenDay string, givenZeekDir ...string) (allRes []QuicEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseQuicRecurse(zeekDir + givenDay + "/")
+	return
+}
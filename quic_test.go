@@ -0,0 +1,32 @@
+package zeekparse
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThisLogEntryToQuicStruct(t *testing.T) {
+	log.SetFormatter(&log.TextFormatter{ForceColors: true})
+	log.SetLevel(log.InfoLevel)
+
+	// compressed case
+	compressedResults, header, compErr := parseZeekLog("test_input/simple_quic.log.gz")
+	for _, thisResult := range compressedResults {
+		_, parseErr := thisLogEntryToQuicStruct(thisResult, header)
+		assert.NoError(t, parseErr)
+	}
+	assert.NoError(t, compErr)
+
+	allQuic, err := ParseQuicLog("test_input/simple_quic.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(allQuic))
+	assert.Equal(t, "docs.google.com", allQuic[0].ServerName)
+	assert.Equal(t, "95412c47018cdfe8", allQuic[0].ClientInitialDcid)
+	assert.Equal(t, "ISishIhHhhH", allQuic[0].History)
+	assert.True(t, allQuic[0].IsHTTP3())
+	assert.True(t, allQuic[1].IsHTTP3())
+	assert.Equal(t, "ff00001d", allQuic[3].Version)
+	assert.Equal(t, "", allQuic[3].ServerName)
+	assert.False(t, allQuic[3].IsHTTP3())
+}
@@ -0,0 +1,149 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"time"
+)
+
+// radius log format described in https://docs.zeek.org/en/master/scripts/base/protocols/radius/main.zeek.html#type-RADIUS::Info
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// RadiusEntry is a fully parsed radius.log line.
+type RadiusEntry struct {
+	TS      time.Time // TS:time - timestamp
+	Uid     string    // Uid:string - unique id
+	IdOrigH string    // id_orig_h:addr - senders address
+	IdOrigP int       // id_orig_p:addr - senders port
+	IdRespH string    // id_resp_h:port - responders address
+	IdRespP int       // id_resp_p:port - responders port
+	// ---------
+	Username     string       // username:string - username if present
+	Mac          string       // mac:string - MAC address if present
+	FramedAddr   string       // framed_addr:addr - address given to the network access server if present
+	TunnelClient string       // tunnel_client:string - address (IPv4, IPv6, or FQDN) of the initiator end of the tunnel if present
+	ConnectInfo  string       // connect_info:string - connect info if present
+	ReplyMsg     string       // reply_msg:string - reply message from the server challenge
+	Result       string       // result:string - successful or failed authentication
+	TTL          Opt[float64] // ttl:interval - duration between the first request and either the "Access-Accept" message or an error
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (r *RadiusEntry) Print() {
+	fmt.Printf("(%s) client {%s:%d} talks to {%s:%d}:\n",
+		r.TS.String(), r.IdOrigH, r.IdOrigP, r.IdRespH, r.IdRespP)
+	fmt.Printf("\t%s (%s) %s %s\n", r.Username, r.Mac, r.Result, r.ReplyMsg)
+}
+
+func (r *RadiusEntry) ShortPrint() {
+	fmt.Printf("[%s] %s %s %s\n", r.TS, r.IdOrigH, r.Username, r.Result)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToRadiusStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (radiusEntry RadiusEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			radiusEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "uid":
+			radiusEntry.Uid = thisField.value
+		case "id.orig_h":
+			radiusEntry.IdOrigH = thisField.value
+		case "id.orig_p":
+			radiusEntry.IdOrigP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id.resp_h":
+			radiusEntry.IdRespH = thisField.value
+		case "id.resp_p":
+			radiusEntry.IdRespP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "username":
+			radiusEntry.Username = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "mac":
+			radiusEntry.Mac = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "framed_addr":
+			radiusEntry.FramedAddr = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "tunnel_client":
+			radiusEntry.TunnelClient = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "connect_info":
+			radiusEntry.ConnectInfo = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "reply_msg":
+			radiusEntry.ReplyMsg = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "result":
+			radiusEntry.Result = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "ttl":
+			radiusEntry.TTL, err = optFloat(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseRadiusLog will parse through the given single radius log (passed as a filename string)
+func ParseRadiusLog(givenFilename string) (parsedResults []RadiusEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes RadiusEntry
+		thisRes, err = thisLogEntryToRadiusStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseRadiusRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseRadiusRecurse(givenDirectory string) (allResults []RadiusEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "radius") {
+		thisResult, parseErr := ParseRadiusLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllRadiusForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed RadiusEntry objects
+func GetAllRadiusForDay(givenDay string, givenZeekDir ...string) (allRes []RadiusEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseRadiusRecurse(zeekDir + givenDay + "/")
+	return
+}
@@ -0,0 +1,29 @@
+package zeekparse
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThisLogEntryToRadiusStruct(t *testing.T) {
+	log.SetFormatter(&log.TextFormatter{ForceColors: true})
+	log.SetLevel(log.InfoLevel)
+
+	// compressed case
+	compressedResults, header, compErr := parseZeekLog("test_input/simple_radius.log.gz")
+	for _, thisResult := range compressedResults {
+		_, parseErr := thisLogEntryToRadiusStruct(thisResult, header)
+		assert.NoError(t, parseErr)
+	}
+	assert.NoError(t, compErr)
+
+	allRadius, err := ParseRadiusLog("test_input/simple_radius.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(allRadius))
+	assert.Equal(t, "jsmith", allRadius[0].Username)
+	assert.Equal(t, "10.0.50.12", allRadius[0].FramedAddr)
+	assert.Equal(t, "failed", allRadius[1].Result)
+	assert.Equal(t, "", allRadius[1].Mac)
+	assert.False(t, allRadius[1].TTL.IsSet())
+}
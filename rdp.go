@@ -0,0 +1,199 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"time"
+)
+
+// rdp log format described in https://docs.zeek.org/en/master/scripts/base/protocols/rdp/main.zeek.html#type-RDP::Info
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// RdpEntry is a fully parsed rdp.log line.
+type RdpEntry struct {
+	TS      time.Time // TS:time - timestamp
+	Uid     string    // Uid:string - unique id
+	IdOrigH string    // id_orig_h:addr - senders address
+	IdOrigP int       // id_orig_p:addr - senders port
+	IdRespH string    // id_resp_h:port - responders address
+	IdRespP int       // id_resp_p:port - responders port
+	// ---------
+	Cookie              string   // cookie:string - cookie value used by the client machine, usually a username
+	Result              string   // result:string - status result for the connection
+	SecurityProtocol    string   // security_protocol:string - security protocol chosen by the server
+	ClientChannels      []string // client_channels:vector[string] - channels requested by the client
+	KeyboardLayout      string   // keyboard_layout:string - keyboard layout (language) of the client machine
+	ClientBuild         string   // client_build:string - RDP client version used by the client machine
+	ClientName          string   // client_name:string - name of the client machine
+	ClientDigProductId  string   // client_dig_product_id:string - product id of the client machine
+	DesktopWidth        Opt[int] // desktop_width:count - desktop width of the client machine
+	DesktopHeight       Opt[int] // desktop_height:count - desktop height of the client machine
+	RequestedColorDepth string   // requested_color_depth:string - color depth requested by the client
+	CertType            string   // cert_type:string - type of certificate used if the connection is encrypted with native RDP encryption
+	CertCount           Opt[int] // cert_count:count - number of certs seen, X.509 can transfer an entire certificate chain
+	CertPermanent       bool     // cert_permanent:bool - indicates if the provided certificate or certificate chain is permanent or temporary
+	EncryptionLevel     string   // encryption_level:string - encryption level of the connection
+	EncryptionMethod    string   // encryption_method:string - encryption method of the connection
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (r *RdpEntry) Print() {
+	fmt.Printf("(%s) client {%s:%d} talks to {%s:%d}:\n",
+		r.TS.String(), r.IdOrigH, r.IdOrigP, r.IdRespH, r.IdRespP)
+	fmt.Printf("\tcookie:%s client:%s result:%s security:%s\n", r.Cookie, r.ClientName, r.Result, r.SecurityProtocol)
+}
+
+func (r *RdpEntry) ShortPrint() {
+	fmt.Printf("[%s] %s -> %s:%d %s %s\n", r.TS, r.IdOrigH, r.IdRespH, r.IdRespP, r.Cookie, r.Result)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToRdpStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (rdpEntry RdpEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			rdpEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "uid":
+			rdpEntry.Uid = thisField.value
+		case "id.orig_h":
+			rdpEntry.IdOrigH = thisField.value
+		case "id.orig_p":
+			rdpEntry.IdOrigP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id.resp_h":
+			rdpEntry.IdRespH = thisField.value
+		case "id.resp_p":
+			rdpEntry.IdRespP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "cookie":
+			rdpEntry.Cookie = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "result":
+			rdpEntry.Result = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "security_protocol":
+			rdpEntry.SecurityProtocol = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "client_channels":
+			rdpEntry.ClientChannels = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "keyboard_layout":
+			rdpEntry.KeyboardLayout = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "client_build":
+			rdpEntry.ClientBuild = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "client_name":
+			rdpEntry.ClientName = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "client_dig_product_id":
+			rdpEntry.ClientDigProductId = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "desktop_width":
+			rdpEntry.DesktopWidth, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "desktop_height":
+			rdpEntry.DesktopHeight, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "requested_color_depth":
+			rdpEntry.RequestedColorDepth = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "cert_type":
+			rdpEntry.CertType = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "cert_count":
+			rdpEntry.CertCount, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "cert_permanent":
+			rdpEntry.CertPermanent = thisField.value == "T"
+		case "encryption_level":
+			rdpEntry.EncryptionLevel = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "encryption_method":
+			rdpEntry.EncryptionMethod = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseRdpLog will parse through the given single rdp log (passed as a filename string)
+func ParseRdpLog(givenFilename string) (parsedResults []RdpEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes RdpEntry
+		thisRes, err = thisLogEntryToRdpStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseRdpRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseRdpRecurse(givenDirectory string) (allResults []RdpEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "rdp") {
+		thisResult, parseErr := ParseRdpLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllRdpForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed RdpEntry objects
+func GetAllRdpForDay(givenDay string, givenZeekDir ...string) (allRes []RdpEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseRdpRecurse(zeekDir + givenDay + "/")
+	return
+}
+
+// ------------------------------
+// ---- Remote Access Report ----
+// ------------------------------
+
+// InboundRdpSessions returns the rdp sessions that were originated from outside the given local
+// networks (as CIDR strings) to a host inside them.  If no networks are given then DefaultLocalNets
+// is used.
+func InboundRdpSessions(givenRdp []RdpEntry, givenLocalNets ...string) (inbound []RdpEntry, err error) {
+	site, err := NewSite(givenLocalNets...)
+	if err != nil {
+		return
+	}
+	for _, thisRdp := range givenRdp {
+		if site.Classify(&thisRdp) == DirectionInbound {
+			inbound = append(inbound, thisRdp)
+		}
+	}
+	return
+}
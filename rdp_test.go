@@ -0,0 +1,43 @@
+package zeekparse
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThisLogEntryToRdpStruct(t *testing.T) {
+	log.SetFormatter(&log.TextFormatter{ForceColors: true})
+	log.SetLevel(log.InfoLevel)
+
+	// compressed case
+	compressedResults, header, compErr := parseZeekLog("test_input/simple_rdp.log.gz")
+	for _, thisResult := range compressedResults {
+		_, parseErr := thisLogEntryToRdpStruct(thisResult, header)
+		assert.NoError(t, parseErr)
+	}
+	assert.NoError(t, compErr)
+}
+
+func TestInboundRdpSessions(t *testing.T) {
+	allRdp, err := ParseRdpLog("test_input/simple_rdp.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(allRdp))
+	assert.Equal(t, []string{"rdpdr", "rdpsnd", "cliprdr"}, allRdp[1].ClientChannels)
+	assert.Equal(t, Some(1920), allRdp[1].DesktopWidth)
+
+	// default private ranges
+	inbound, err := InboundRdpSessions(allRdp)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(inbound))
+	assert.Equal(t, "administr", inbound[0].Cookie)
+
+	// explicit local networks
+	inbound, err = InboundRdpSessions(allRdp, "192.168.1.0/24", "198.51.100.0/24")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(inbound))
+	assert.Equal(t, "CRdp3a2b3c4d5e6f7g", inbound[0].Uid)
+
+	_, err = InboundRdpSessions(allRdp, "not a cidr")
+	assert.Error(t, err)
+}
@@ -0,0 +1,121 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+// reporter log format described in https://docs.zeek.org/en/master/scripts/base/frameworks/reporter/main.zeek.html#type-Reporter::Info
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// ReporterEntry is a fully parsed reporter.log line.
+type ReporterEntry struct {
+	TS time.Time // TS:time - timestamp
+	// ---------
+	Level    string // level:enum - the severity of the reporter message (ie: Reporter::ERROR)
+	Message  string // message:string - an info/warning/error message that could have either been generated from the internal zeek core or at the scripting-layer
+	Location string // location:string - location in code where the message was generated if any
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (r *ReporterEntry) Print() {
+	fmt.Printf("(%s) %s %s %s\n", r.TS.String(), r.Level, r.Location, r.Message)
+}
+
+func (r *ReporterEntry) ShortPrint() {
+	fmt.Printf("[%s] %s %s\n", r.TS, r.Level, r.Message)
+}
+
+// ------------------------------
+// ----    Entry Helpers   ------
+// ------------------------------
+
+// IsError tells if this is an error level message.
+func (r *ReporterEntry) IsError() bool {
+	return r.Level == "Reporter::ERROR"
+}
+
+// IsWarning tells if this is a warning level message.
+func (r *ReporterEntry) IsWarning() bool {
+	return r.Level == "Reporter::WARNING"
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToReporterStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (reporterEntry ReporterEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			reporterEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "level":
+			reporterEntry.Level = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "message":
+			reporterEntry.Message = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "location":
+			reporterEntry.Location = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseReporterLog will parse through the given single reporter log (passed as a filename string)
+func ParseReporterLog(givenFilename string) (parsedResults []ReporterEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes ReporterEntry
+		thisRes, err = thisLogEntryToReporterStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseReporterRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseReporterRecurse(givenDirectory string) (allResults []ReporterEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "reporter") {
+		thisResult, parseErr := ParseReporterLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllReporterForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed ReporterEntry objects
+func GetAllReporterForDay(givenDay string, givenZeekDir ...string) (allRes []ReporterEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseReporterRecurse(zeekDir + givenDay + "/")
+	return
+}
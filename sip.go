@@ -0,0 +1,197 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"time"
+)
+
+// sip log format described in https://docs.zeek.org/en/master/scripts/base/protocols/sip/main.zeek.html#type-SIP::Info
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// SipEntry is a fully parsed sip.log line.
+type SipEntry struct {
+	TS      time.Time // TS:time - timestamp
+	Uid     string    // Uid:string - unique id
+	IdOrigH string    // id_orig_h:addr - senders address
+	IdOrigP int       // id_orig_p:addr - senders port
+	IdRespH string    // id_resp_h:port - responders address
+	IdRespP int       // id_resp_p:port - responders port
+	// ---------
+	TransDepth      Opt[int] // trans_depth:count - represents the pipelined depth into the connection of this request/response transaction
+	Method          string   // method:string - verb used in the SIP request (INVITE, REGISTER etc.)
+	Uri             string   // uri:string - URI used in the request
+	Date            string   // date:string - contents of the Date: header from the client
+	RequestFrom     string   // request_from:string - contents of the request From: header
+	RequestTo       string   // request_to:string - contents of the To: header
+	ResponseFrom    string   // response_from:string - contents of the response From: header
+	ResponseTo      string   // response_to:string - contents of the response To: header
+	ReplyTo         string   // reply_to:string - contents of the Reply-To: header
+	CallId          string   // call_id:string - contents of the Call-ID: header from the client
+	Seq             string   // seq:string - contents of the CSeq: header from the client
+	Subject         string   // subject:string - contents of the Subject: header from the client
+	RequestPath     []string // request_path:vector[string] - client message transmission path, as extracted from the headers
+	ResponsePath    []string // response_path:vector[string] - server message transmission path, as extracted from the headers
+	UserAgent       string   // user_agent:string - contents of the User-Agent: header from the client
+	StatusCode      Opt[int] // status_code:count - status code returned by the server
+	StatusMsg       string   // status_msg:string - status message returned by the server
+	Warning         string   // warning:string - contents of the Warning: header
+	RequestBodyLen  Opt[int] // request_body_len:count - contents of the Content-Length: header from the client
+	ResponseBodyLen Opt[int] // response_body_len:count - contents of the Content-Length: header from the server
+	ContentType     string   // content_type:string - contents of the Content-Type: header from the server
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (s *SipEntry) Print() {
+	fmt.Printf("(%s) client {%s:%d} talks to {%s:%d}:\n",
+		s.TS.String(), s.IdOrigH, s.IdOrigP, s.IdRespH, s.IdRespP)
+	fmt.Printf("\t%s %s -> %s %s\n", s.Method, s.Uri, s.StatusCode, s.StatusMsg)
+}
+
+func (s *SipEntry) ShortPrint() {
+	fmt.Printf("[%s] %s %s %s -> %s\n", s.TS, s.IdOrigH, s.Method, s.Uri, s.StatusCode)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToSipStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (sipEntry SipEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			sipEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "uid":
+			sipEntry.Uid = thisField.value
+		case "id.orig_h":
+			sipEntry.IdOrigH = thisField.value
+		case "id.orig_p":
+			sipEntry.IdOrigP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id.resp_h":
+			sipEntry.IdRespH = thisField.value
+		case "id.resp_p":
+			sipEntry.IdRespP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "trans_depth":
+			sipEntry.TransDepth, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "method":
+			sipEntry.Method = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "uri":
+			sipEntry.Uri = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "date":
+			sipEntry.Date = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "request_from":
+			sipEntry.RequestFrom = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "request_to":
+			sipEntry.RequestTo = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "response_from":
+			sipEntry.ResponseFrom = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "response_to":
+			sipEntry.ResponseTo = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "reply_to":
+			sipEntry.ReplyTo = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "call_id":
+			sipEntry.CallId = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "seq":
+			sipEntry.Seq = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "subject":
+			sipEntry.Subject = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "request_path":
+			sipEntry.RequestPath = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "response_path":
+			sipEntry.ResponsePath = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "user_agent":
+			sipEntry.UserAgent = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "status_code":
+			sipEntry.StatusCode, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "status_msg":
+			sipEntry.StatusMsg = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "warning":
+			sipEntry.Warning = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "request_body_len":
+			sipEntry.RequestBodyLen, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "response_body_len":
+			sipEntry.ResponseBodyLen, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "content_type":
+			sipEntry.ContentType = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseSipLog will parse through the given single sip log (passed as a filename string)
+func ParseSipLog(givenFilename string) (parsedResults []SipEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes SipEntry
+		thisRes, err = thisLogEntryToSipStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseSipRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseSipRecurse(givenDirectory string) (allResults []SipEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "sip") {
+		thisResult, parseErr := ParseSipLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllSipForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed SipEntry objects
+func GetAllSipForDay(givenDay string, givenZeekDir ...string) (allRes []SipEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseSipRecurse(zeekDir + givenDay + "/")
+	return
+}
@@ -0,0 +1,31 @@
+package zeekparse
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThisLogEntryToSipStruct(t *testing.T) {
+	log.SetFormatter(&log.TextFormatter{ForceColors: true})
+	log.SetLevel(log.InfoLevel)
+
+	// compressed case
+	compressedResults, header, compErr := parseZeekLog("test_input/simple_sip.log.gz")
+	for _, thisResult := range compressedResults {
+		_, parseErr := thisLogEntryToSipStruct(thisResult, header)
+		assert.NoError(t, parseErr)
+	}
+	assert.NoError(t, compErr)
+
+	allSip, err := ParseSipLog("test_input/simple_sip.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(allSip))
+	assert.Equal(t, "OPTIONS", allSip[0].Method)
+	assert.Equal(t, "friendly-scanner", allSip[0].UserAgent)
+	assert.Equal(t, Some(200), allSip[0].StatusCode)
+	assert.Equal(t, []string{"SIP/2.0/UDP 192.168.1.30:5060"}, allSip[1].RequestPath)
+	assert.Nil(t, allSip[1].ResponsePath)
+	assert.False(t, allSip[1].StatusCode.IsSet())
+	assert.Equal(t, Some(142), allSip[1].RequestBodyLen)
+}
@@ -0,0 +1,176 @@
+/*
+Describes the local site so entries from any log can be classified by direction.  This is the
+same idea as zeek's Site::local_nets but done at analysis time, so it works on logs from
+sensors that never had local_nets configured.
+*/
+
+package zeekparse
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"strings"
+)
+
+// DefaultLocalNets are the private address ranges used when no local networks are given.
+var DefaultLocalNets = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7"}
+
+// Direction is which way a connection crosses the site boundary.
+type Direction string
+
+const (
+	DirectionInbound  Direction = "inbound"  // remote originator to a local responder
+	DirectionOutbound Direction = "outbound" // local originator to a remote responder
+	DirectionInternal Direction = "internal" // both ends local
+	DirectionExternal Direction = "external" // neither end local
+	DirectionUnknown  Direction = "unknown"  // an endpoint address was not logged or didn't parse
+)
+
+// directionFromLocal works out the direction from whether each end is local.
+func directionFromLocal(givenOrigLocal, givenRespLocal bool) Direction {
+	switch {
+	case givenOrigLocal && givenRespLocal:
+		return DirectionInternal
+	case givenOrigLocal:
+		return DirectionOutbound
+	case givenRespLocal:
+		return DirectionInbound
+	}
+	return DirectionExternal
+}
+
+// Endpoints is any entry that has an originator and responder, which is every log keyed
+// on a connection.
+type Endpoints interface {
+	ConnID() ConnID
+}
+
+// SiteNet is a single local network and its description if one was given.
+type SiteNet struct {
+	Prefix      netip.Prefix
+	Description string
+}
+
+// Site is the set of networks considered local.
+type Site struct {
+	Nets []SiteNet
+}
+
+// NewSite returns a Site with the given networks (as CIDR strings) as local.  If no networks
+// are given then DefaultLocalNets is used.
+func NewSite(givenNets ...string) (site *Site, err error) {
+	if len(givenNets) == 0 {
+		givenNets = DefaultLocalNets
+	}
+	prefixes, err := ParsePrefixes(givenNets...)
+	if err != nil {
+		return
+	}
+	site = new(Site)
+	for _, thisPrefix := range prefixes {
+		site.Nets = append(site.Nets, SiteNet{Prefix: thisPrefix})
+	}
+	return
+}
+
+// ReadSite reads a zeekctl networks.cfg style list of local networks, one CIDR per line
+// followed by an optional description.  Blank lines and lines starting with # are skipped.
+func ReadSite(givenReader io.Reader) (site *Site, err error) {
+	site = new(Site)
+	scanner := bufio.NewScanner(givenReader)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		var prefix netip.Prefix
+		prefix, err = netip.ParsePrefix(fields[0])
+		if err != nil {
+			err = fmt.Errorf("networks line %d: %w", lineNum, err)
+			return
+		}
+		site.Nets = append(site.Nets, SiteNet{
+			Prefix:      prefix.Masked(),
+			Description: strings.Join(fields[1:], " "),
+		})
+	}
+	err = scanner.Err()
+	return
+}
+
+// LoadSiteFile reads the local networks from the given networks.cfg style file.
+func LoadSiteFile(givenFilename string) (site *Site, err error) {
+	f, err := os.Open(givenFilename)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	site, err = ReadSite(f)
+	return
+}
+
+// Prefixes returns the local networks as prefixes.
+func (s *Site) Prefixes() (prefixes []netip.Prefix) {
+	for _, thisNet := range s.Nets {
+		prefixes = append(prefixes, thisNet.Prefix)
+	}
+	return
+}
+
+// NetFor returns the most specific local network containing the given address.
+func (s *Site) NetFor(givenAddr netip.Addr) (found SiteNet, ok bool) {
+	if !givenAddr.IsValid() {
+		return
+	}
+	givenAddr = givenAddr.Unmap()
+	for _, thisNet := range s.Nets {
+		if thisNet.Prefix.Contains(givenAddr) && (!ok || thisNet.Prefix.Bits() > found.Prefix.Bits()) {
+			found = thisNet
+			ok = true
+		}
+	}
+	return
+}
+
+// IsLocal tells if the given address is within any of the local networks.
+func (s *Site) IsLocal(givenAddr netip.Addr) bool {
+	_, ok := s.NetFor(givenAddr)
+	return ok
+}
+
+// IsLocalStr tells if the given address string is within any of the local networks.
+func (s *Site) IsLocalStr(givenAddress string) bool {
+	addr, err := netip.ParseAddr(givenAddress)
+	if err != nil {
+		return false
+	}
+	return s.IsLocal(addr)
+}
+
+// Direction classifies the given endpoints against the local networks.
+func (s *Site) Direction(givenID ConnID) Direction {
+	if !givenID.IsValid() {
+		return DirectionUnknown
+	}
+	return directionFromLocal(s.IsLocal(givenID.Orig.Addr()), s.IsLocal(givenID.Resp.Addr()))
+}
+
+// Classify returns the direction of the given entry, which can be from any connection log.
+func (s *Site) Classify(givenEntry Endpoints) Direction {
+	return s.Direction(givenEntry.ConnID())
+}
+
+// ZeekDirection returns the direction zeek itself logged through local_orig and local_resp.
+// This is DirectionUnknown if the sensor did not have Site::local_nets set.
+func (c *ConnEntry) ZeekDirection() Direction {
+	if !c.LocalOrig.IsSet() || !c.LocalResp.IsSet() {
+		return DirectionUnknown
+	}
+	return directionFromLocal(c.LocalOrig.Value(), c.LocalResp.Value())
+}
@@ -0,0 +1,75 @@
+package zeekparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+func TestNewSite(t *testing.T) {
+	site, err := NewSite()
+	assert.NoError(t, err)
+	assert.Equal(t, len(DefaultLocalNets), len(site.Nets))
+	assert.True(t, site.IsLocalStr("10.1.2.3"))
+	assert.True(t, site.IsLocalStr("fd00::53"))
+	assert.False(t, site.IsLocalStr("8.8.8.8"))
+	assert.False(t, site.IsLocalStr(""))
+
+	site, err = NewSite("192.168.1.0/24")
+	assert.NoError(t, err)
+	assert.Equal(t, []netip.Prefix{netip.MustParsePrefix("192.168.1.0/24")}, site.Prefixes())
+	assert.False(t, site.IsLocalStr("10.1.2.3"))
+
+	_, err = NewSite("not a cidr")
+	assert.Error(t, err)
+}
+
+func TestLoadSiteFile(t *testing.T) {
+	site, err := LoadSiteFile("test_input/networks.cfg")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, len(site.Nets))
+	assert.Equal(t, "Private IP space", site.Nets[0].Description)
+
+	// the most specific network wins
+	found, ok := site.NetFor(netip.MustParseAddr("192.168.1.110"))
+	assert.True(t, ok)
+	assert.Equal(t, "Office LAN", found.Description)
+	found, ok = site.NetFor(netip.MustParseAddr("192.168.7.1"))
+	assert.True(t, ok)
+	assert.Equal(t, "Private IP space", found.Description)
+	_, ok = site.NetFor(netip.MustParseAddr("8.8.8.8"))
+	assert.False(t, ok)
+
+	_, err = ReadSite(strings.NewReader("# comment\n\n10.0.0.0/8\nbad line here\n"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "line 4")
+
+	_, err = LoadSiteFile("test_input/does_not_exist.cfg")
+	assert.Error(t, err)
+}
+
+func TestSiteClassify(t *testing.T) {
+	site, err := NewSite("192.168.1.0/24", "2001:db8::/32")
+	assert.NoError(t, err)
+
+	assert.Equal(t, DirectionOutbound, site.Direction(NewConnID("192.168.1.110", 51515, "93.184.216.34", 443)))
+	assert.Equal(t, DirectionInbound, site.Direction(NewConnID("203.0.113.9", 40000, "192.168.1.10", 3389)))
+	assert.Equal(t, DirectionInternal, site.Direction(NewConnID("192.168.1.110", 51515, "192.168.1.1", 53)))
+	assert.Equal(t, DirectionExternal, site.Direction(NewConnID("203.0.113.9", 40000, "93.184.216.34", 443)))
+	assert.Equal(t, DirectionUnknown, site.Direction(NewConnID("", 0, "192.168.1.1", 53)))
+
+	allConn, err := ParseConnLog("test_input/simple_conn_full.log")
+	assert.NoError(t, err)
+	assert.Equal(t, DirectionOutbound, site.Classify(&allConn[0]))
+	assert.Equal(t, DirectionInternal, site.Classify(&allConn[2]))
+	assert.Equal(t, DirectionOutbound, allConn[0].ZeekDirection())
+	assert.Equal(t, DirectionInternal, allConn[2].ZeekDirection())
+
+	// any log keyed on a connection can be classified
+	allWeird, err := ParseWeirdLog("test_input/simple_weird.log.gz")
+	assert.NoError(t, err)
+	assert.Equal(t, DirectionOutbound, site.Classify(&allWeird[0]))
+	// weirds not tied to a connection have no endpoints
+	assert.Equal(t, DirectionUnknown, site.Classify(&allWeird[len(allWeird)-1]))
+}
@@ -0,0 +1,175 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"time"
+)
+
+// smb_files log format described in https://docs.zeek.org/en/master/scripts/base/protocols/smb/main.zeek.html#type-SMB::FileInfo
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// SmbFilesEntry is a fully parsed smb_files.log line.
+type SmbFilesEntry struct {
+	TS      time.Time // TS:time - timestamp
+	Uid     string    // Uid:string - unique id
+	IdOrigH string    // id_orig_h:addr - senders address
+	IdOrigP int       // id_orig_p:addr - senders port
+	IdRespH string    // id_resp_h:port - responders address
+	IdRespP int       // id_resp_p:port - responders port
+	// ---------
+	Fuid          string    // fuid:string - unique id of the file
+	Action        string    // action:enum - action this log record represents (ie: SMB::FILE_OPEN)
+	Path          string    // path:string - path pulled from the tree this file was transferred to or from
+	Name          string    // name:string - filename if one was seen
+	Size          Opt[int]  // size:count - total size of the file
+	PrevName      string    // prev_name:string - if the rename action was seen, this will be the file's previous name
+	TimesModified time.Time // times.modified:time - last time this file was modified
+	TimesAccessed time.Time // times.accessed:time - last time this file was accessed
+	TimesCreated  time.Time // times.created:time - time this file was created
+	TimesChanged  time.Time // times.changed:time - time when the file was last changed
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (s *SmbFilesEntry) Print() {
+	fmt.Printf("(%s) client {%s:%d} talks to {%s:%d}:\n",
+		s.TS.String(), s.IdOrigH, s.IdOrigP, s.IdRespH, s.IdRespP)
+	fmt.Printf("\t%s %s%s (%s bytes)\n", s.Action, s.Path, s.Name, s.Size)
+}
+
+func (s *SmbFilesEntry) ShortPrint() {
+	fmt.Printf("[%s] %s %s %s\\%s\n", s.TS, s.IdOrigH, s.Action, s.Path, s.Name)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToSmbFilesStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (smbFilesEntry SmbFilesEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			smbFilesEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "uid":
+			smbFilesEntry.Uid = thisField.value
+		case "id.orig_h":
+			smbFilesEntry.IdOrigH = thisField.value
+		case "id.orig_p":
+			smbFilesEntry.IdOrigP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id.resp_h":
+			smbFilesEntry.IdRespH = thisField.value
+		case "id.resp_p":
+			smbFilesEntry.IdRespP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "fuid":
+			smbFilesEntry.Fuid = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "action":
+			smbFilesEntry.Action = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "path":
+			smbFilesEntry.Path = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "name":
+			smbFilesEntry.Name = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "size":
+			smbFilesEntry.Size, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "prev_name":
+			smbFilesEntry.PrevName = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "times.modified":
+			if thisField.value != givenLogOpts.unsetField {
+				smbFilesEntry.TimesModified, err = UnixStrToTime(thisField.value)
+				if err != nil {
+					return
+				}
+			}
+		case "times.accessed":
+			if thisField.value != givenLogOpts.unsetField {
+				smbFilesEntry.TimesAccessed, err = UnixStrToTime(thisField.value)
+				if err != nil {
+					return
+				}
+			}
+		case "times.created":
+			if thisField.value != givenLogOpts.unsetField {
+				smbFilesEntry.TimesCreated, err = UnixStrToTime(thisField.value)
+				if err != nil {
+					return
+				}
+			}
+		case "times.changed":
+			if thisField.value != givenLogOpts.unsetField {
+				smbFilesEntry.TimesChanged, err = UnixStrToTime(thisField.value)
+				if err != nil {
+					return
+				}
+			}
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseSmbFilesLog will parse through the given single smb_files log (passed as a filename string)
+func ParseSmbFilesLog(givenFilename string) (parsedResults []SmbFilesEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes SmbFilesEntry
+		thisRes, err = thisLogEntryToSmbFilesStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseSmbFilesRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseSmbFilesRecurse(givenDirectory string) (allResults []SmbFilesEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "smb_files") {
+		thisResult, parseErr := ParseSmbFilesLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllSmbFilesForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed SmbFilesEntry objects
+func GetAllSmbFilesForDay(givenDay string, givenZeekDir ...string) (allRes []SmbFilesEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseSmbFilesRecurse(zeekDir + givenDay + "/")
+	return
+}
@@ -0,0 +1,134 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"time"
+)
+
+// smb_mapping log format described in https://docs.zeek.org/en/master/scripts/base/protocols/smb/main.zeek.html#type-SMB::TreeInfo
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// SmbMappingEntry is a fully parsed smb_mapping.log line.
+type SmbMappingEntry struct {
+	TS      time.Time // TS:time - timestamp
+	Uid     string    // Uid:string - unique id
+	IdOrigH string    // id_orig_h:addr - senders address
+	IdOrigP int       // id_orig_p:addr - senders port
+	IdRespH string    // id_resp_h:port - responders address
+	IdRespP int       // id_resp_p:port - responders port
+	// ---------
+	Path             string // path:string - name of the tree path
+	Service          string // service:string - type of resource of the tree (disk share, printer share, named pipe, etc.)
+	NativeFileSystem string // native_file_system:string - file system of the tree
+	ShareType        string // share_type:string - type of share being accessed (DISK, PIPE, PRINT, ...)
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (s *SmbMappingEntry) Print() {
+	fmt.Printf("(%s) client {%s:%d} talks to {%s:%d}:\n",
+		s.TS.String(), s.IdOrigH, s.IdOrigP, s.IdRespH, s.IdRespP)
+	fmt.Printf("\t%s (%s)\n", s.Path, s.ShareType)
+}
+
+func (s *SmbMappingEntry) ShortPrint() {
+	fmt.Printf("[%s] %s -> %s\n", s.TS, s.IdOrigH, s.Path)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToSmbMappingStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (smbMappingEntry SmbMappingEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			smbMappingEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "uid":
+			smbMappingEntry.Uid = thisField.value
+		case "id.orig_h":
+			smbMappingEntry.IdOrigH = thisField.value
+		case "id.orig_p":
+			smbMappingEntry.IdOrigP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id.resp_h":
+			smbMappingEntry.IdRespH = thisField.value
+		case "id.resp_p":
+			smbMappingEntry.IdRespP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "path":
+			smbMappingEntry.Path = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "service":
+			smbMappingEntry.Service = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "native_file_system":
+			smbMappingEntry.NativeFileSystem = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "share_type":
+			smbMappingEntry.ShareType = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseSmbMappingLog will parse through the given single smb_mapping log (passed as a filename string)
+func ParseSmbMappingLog(givenFilename string) (parsedResults []SmbMappingEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes SmbMappingEntry
+		thisRes, err = thisLogEntryToSmbMappingStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseSmbMappingRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseSmbMappingRecurse(givenDirectory string) (allResults []SmbMappingEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "smb_mapping") {
+		thisResult, parseErr := ParseSmbMappingLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllSmbMappingForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed SmbMappingEntry objects
+func GetAllSmbMappingForDay(givenDay string, givenZeekDir ...string) (allRes []SmbMappingEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseSmbMappingRecurse(zeekDir + givenDay + "/")
+	return
+}
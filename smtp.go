@@ -0,0 +1,271 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// smtp log format described in https://docs.zeek.org/en/master/scripts/base/protocols/smtp/main.zeek.html#type-SMTP::Info
+
+// ------------------------------
+// ----- Helper Structures ------
+// ------------------------------
+
+// EmailAddress is an email address split into its display name, local part and domain.
+type EmailAddress struct {
+	Name   string
+	Local  string
+	Domain string
+}
+
+// String returns the address in local@domain form without the display name.
+func (e EmailAddress) String() string {
+	return e.Local + "@" + e.Domain
+}
+
+// ParseEmailAddress will parse a single address as seen in smtp headers and envelopes
+// (ie: "Bob <bob@example.com>" or "<bob@example.com>") into an EmailAddress.
+// The domain is lowercased.
+func ParseEmailAddress(givenAddress string) (addr EmailAddress, err error) {
+	givenAddress = strings.TrimSpace(givenAddress)
+	var rawAddr string
+
+	parsed, parseErr := mail.ParseAddress(givenAddress)
+	if parseErr == nil {
+		addr.Name = parsed.Name
+		rawAddr = parsed.Address
+	} else {
+		// envelope addresses are frequently not RFC 5322 compliant so fall back to
+		// pulling out whatever is between the angle brackets.
+		rawAddr = givenAddress
+		if start := strings.LastIndex(rawAddr, "<"); start >= 0 {
+			rawAddr = rawAddr[start+1:]
+		}
+		rawAddr = strings.TrimSuffix(rawAddr, ">")
+	}
+
+	at := strings.LastIndex(rawAddr, "@")
+	if at <= 0 || at == len(rawAddr)-1 {
+		err = errors.New("not a valid email address")
+		return
+	}
+	addr.Local = rawAddr[:at]
+	addr.Domain = strings.ToLower(rawAddr[at+1:])
+	return
+}
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// SmtpEntry is a fully parsed smtp.log line.
+type SmtpEntry struct {
+	TS      time.Time // TS:time - timestamp
+	Uid     string    // Uid:string - unique id
+	IdOrigH string    // id_orig_h:addr - senders address
+	IdOrigP int       // id_orig_p:addr - senders port
+	IdRespH string    // id_resp_h:port - responders address
+	IdRespP int       // id_resp_p:port - responders port
+	// ---------
+	TransDepth     Opt[int] // trans_depth:count - transaction depth if there are multiple msgs
+	Helo           string   // helo:string - contents of the helo header
+	MailFrom       string   // mailfrom:string - email addresses found in the from header
+	RcptTo         []string // rcptto:set[string] - email addresses found in the rcpt header
+	Date           string   // date:string - contents of the date header
+	From           string   // from:string - contents of the from header
+	To             []string // to:set[string] - contents of the to header
+	CC             []string // cc:set[string] - contents of the cc header
+	ReplyTo        string   // reply_to:string - contents of the reply-to header
+	MsgId          string   // msg_id:string - contents of the message-id header
+	InReplyTo      string   // in_reply_to:string - contents of the in-reply-to header
+	Subject        string   // subject:string - contents of the subject header
+	XOriginatingIp string   // x_originating_ip:addr - contents of the x-originating-ip header
+	FirstReceived  string   // first_received:string - contents of the first received header
+	SecondReceived string   // second_received:string - contents of the second received header
+	LastReply      string   // last_reply:string - last message the server sent to the client
+	Path           []string // path:vector[addr] - message transmission path, taken from headers
+	UserAgent      string   // user_agent:string - value of the user-agent header from the client
+	TLS            bool     // tls:bool - indicates that the connection has switched to using TLS
+	Fuids          []string // fuids:vector[string] - file unique ids seen attached to the message
+	IsWebmail      bool     // is_webmail:bool - boolean indicator of if the message was sent through a webmail interface
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (s *SmtpEntry) Print() {
+	fmt.Printf("(%s) client {%s:%d} talks to {%s:%d}:\n",
+		s.TS.String(), s.IdOrigH, s.IdOrigP, s.IdRespH, s.IdRespP)
+	fmt.Printf("\tFROM:%s TO:%s SUBJECT:%s\n", s.MailFrom, s.RcptTo, s.Subject)
+}
+
+func (s *SmtpEntry) ShortPrint() {
+	fmt.Printf("[%s] %s -> %s %s\n", s.TS, s.MailFrom, s.RcptTo, s.Subject)
+}
+
+// ------------------------------
+// ----    Entry Helpers   ------
+// ------------------------------
+
+// SenderDomains returns the unique domains of the envelope sender, the From header and
+// the Reply-To header.  Mismatches between these are a common phishing indicator.
+func (s *SmtpEntry) SenderDomains() (domains []string) {
+	seen := make(map[string]bool)
+	for _, thisAddr := range []string{s.MailFrom, s.From, s.ReplyTo} {
+		if len(thisAddr) == 0 {
+			continue
+		}
+		parsed, err := ParseEmailAddress(thisAddr)
+		if err != nil || seen[parsed.Domain] {
+			continue
+		}
+		seen[parsed.Domain] = true
+		domains = append(domains, parsed.Domain)
+	}
+	return
+}
+
+// RelayPath returns the message transmission path as parsed addresses.  Entries that
+// don't parse as an IP are skipped.
+func (s *SmtpEntry) RelayPath() (path []net.IP) {
+	for _, thisHop := range s.Path {
+		if ip := net.ParseIP(thisHop); ip != nil {
+			path = append(path, ip)
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToSmtpStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (smtpEntry SmtpEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			smtpEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "uid":
+			smtpEntry.Uid = thisField.value
+		case "id.orig_h":
+			smtpEntry.IdOrigH = thisField.value
+		case "id.orig_p":
+			smtpEntry.IdOrigP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id.resp_h":
+			smtpEntry.IdRespH = thisField.value
+		case "id.resp_p":
+			smtpEntry.IdRespP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "trans_depth":
+			smtpEntry.TransDepth, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "helo":
+			smtpEntry.Helo = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "mailfrom":
+			smtpEntry.MailFrom = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "rcptto":
+			smtpEntry.RcptTo = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "date":
+			smtpEntry.Date = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "from":
+			smtpEntry.From = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "to":
+			smtpEntry.To = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "cc":
+			smtpEntry.CC = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "reply_to":
+			smtpEntry.ReplyTo = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "msg_id":
+			smtpEntry.MsgId = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "in_reply_to":
+			smtpEntry.InReplyTo = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "subject":
+			smtpEntry.Subject = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "x_originating_ip":
+			smtpEntry.XOriginatingIp = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "first_received":
+			smtpEntry.FirstReceived = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "second_received":
+			smtpEntry.SecondReceived = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "last_reply":
+			smtpEntry.LastReply = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "path":
+			smtpEntry.Path = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "user_agent":
+			smtpEntry.UserAgent = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "tls":
+			smtpEntry.TLS = thisField.value == "T"
+		case "fuids":
+			smtpEntry.Fuids = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "is_webmail":
+			smtpEntry.IsWebmail = thisField.value == "T"
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseSmtpLog will parse through the given single smtp log (passed as a filename string)
+func ParseSmtpLog(givenFilename string) (parsedResults []SmtpEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var smtpRes SmtpEntry
+		smtpRes, err = thisLogEntryToSmtpStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, smtpRes)
+	}
+	return
+}
+
+// ParseSmtpRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseSmtpRecurse(givenDirectory string) (allResults []SmtpEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "smtp") {
+		thisResult, parseErr := ParseSmtpLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllSmtpForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed SmtpEntry objects
+func GetAllSmtpForDay(givenDay string, givenZeekDir ...string) (allRes []SmtpEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseSmtpRecurse(zeekDir + givenDay + "/")
+	return
+}
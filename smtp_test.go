@@ -0,0 +1,52 @@
+package zeekparse
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThisLogEntryToSmtpStruct(t *testing.T) {
+	log.SetFormatter(&log.TextFormatter{ForceColors: true})
+	log.SetLevel(log.InfoLevel)
+
+	// compressed case
+	compressedResults, header, compErr := parseZeekLog("test_input/simple_smtp.log.gz")
+	for _, thisResult := range compressedResults {
+		_, smtpErr := thisLogEntryToSmtpStruct(thisResult, header)
+		assert.NoError(t, smtpErr)
+	}
+	assert.NoError(t, compErr)
+}
+
+func TestParseEmailAddress(t *testing.T) {
+	addr, err := ParseEmailAddress("\"Gurpartap Singh\" <gurpartap@Patriots.in>")
+	assert.NoError(t, err)
+	assert.Equal(t, "Gurpartap Singh", addr.Name)
+	assert.Equal(t, "gurpartap", addr.Local)
+	assert.Equal(t, "patriots.in", addr.Domain)
+
+	// envelope style address that net/mail won't accept
+	addr, err = ParseEmailAddress("<odd..local@example.org>")
+	assert.NoError(t, err)
+	assert.Equal(t, "odd..local@example.org", addr.String())
+
+	_, err = ParseEmailAddress("not an address")
+	assert.Error(t, err)
+}
+
+func TestSmtpEntryHelpers(t *testing.T) {
+	allSmtp, err := ParseSmtpLog("test_input/simple_smtp.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(allSmtp))
+
+	assert.Equal(t, []string{"patriots.in"}, allSmtp[0].SenderDomains())
+	assert.Equal(t, []string{"examp1e-payments.com", "example.com", "evil.example.net"}, allSmtp[1].SenderDomains())
+
+	assert.Equal(t, 2, len(allSmtp[1].RcptTo))
+	assert.Nil(t, allSmtp[1].Fuids)
+
+	path := allSmtp[0].RelayPath()
+	assert.Equal(t, 2, len(path))
+	assert.Equal(t, "10.10.1.4", path[1].String())
+}
@@ -0,0 +1,169 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"time"
+)
+
+// snmp log format described in https://docs.zeek.org/en/master/scripts/base/protocols/snmp/main.zeek.html#type-SNMP::Info
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// SnmpEntry is a fully parsed snmp.log line.
+type SnmpEntry struct {
+	TS      time.Time // TS:time - timestamp
+	Uid     string    // Uid:string - unique id
+	IdOrigH string    // id_orig_h:addr - senders address
+	IdOrigP int       // id_orig_p:addr - senders port
+	IdRespH string    // id_resp_h:port - responders address
+	IdRespP int       // id_resp_p:port - responders port
+	// ---------
+	Duration        Opt[float64] // duration:interval - amount of time between the first packet belonging to the SNMP session and the latest one seen
+	Version         string       // version:string - version of SNMP being used
+	Community       string       // community:string - community string of the first SNMP packet associated with the session
+	GetRequests     Opt[int]     // get_requests:count - number of variable bindings in GetRequest/GetNextRequest PDUs seen for the session
+	GetBulkRequests Opt[int]     // get_bulk_requests:count - number of variable bindings in GetBulkRequest PDUs seen for the session
+	GetResponses    Opt[int]     // get_responses:count - number of variable bindings in GetResponse/Response PDUs seen for the session
+	SetRequests     Opt[int]     // set_requests:count - number of variable bindings in SetRequest PDUs seen for the session
+	DisplayString   string       // display_string:string - system description of the SNMP responder endpoint
+	UpSince         time.Time    // up_since:time - time at which the SNMP responder endpoint claims it's been up since
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (s *SnmpEntry) Print() {
+	fmt.Printf("(%s) client {%s:%d} talks to {%s:%d}:\n",
+		s.TS.String(), s.IdOrigH, s.IdOrigP, s.IdRespH, s.IdRespP)
+	fmt.Printf("\t%s community:%s gets:%s sets:%s %s\n", s.Version, s.Community, s.GetRequests, s.SetRequests, s.DisplayString)
+}
+
+func (s *SnmpEntry) ShortPrint() {
+	fmt.Printf("[%s] %s -> %s %s %s\n", s.TS, s.IdOrigH, s.IdRespH, s.Version, s.Community)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToSnmpStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (snmpEntry SnmpEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			snmpEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "uid":
+			snmpEntry.Uid = thisField.value
+		case "id.orig_h":
+			snmpEntry.IdOrigH = thisField.value
+		case "id.orig_p":
+			snmpEntry.IdOrigP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id.resp_h":
+			snmpEntry.IdRespH = thisField.value
+		case "id.resp_p":
+			snmpEntry.IdRespP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "duration":
+			snmpEntry.Duration, err = optFloat(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "version":
+			snmpEntry.Version = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "community":
+			snmpEntry.Community = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "get_requests":
+			snmpEntry.GetRequests, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "get_bulk_requests":
+			snmpEntry.GetBulkRequests, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "get_responses":
+			snmpEntry.GetResponses, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "set_requests":
+			snmpEntry.SetRequests, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "display_string":
+			snmpEntry.DisplayString = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "up_since":
+			if thisField.value != givenLogOpts.unsetField {
+				snmpEntry.UpSince, err = UnixStrToTime(thisField.value)
+				if err != nil {
+					return
+				}
+			}
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseSnmpLog will parse through the given single snmp log (passed as a filename string)
+func ParseSnmpLog(givenFilename string) (parsedResults []SnmpEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes SnmpEntry
+		thisRes, err = thisLogEntryToSnmpStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseSnmpRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseSnmpRecurse(givenDirectory string) (allResults []SnmpEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "snmp") {
+		thisResult, parseErr := ParseSnmpLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllSnmpForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed SnmpEntry objects
+func GetAllSnmpForDay(givenDay string, givenZeekDir ...string) (allRes []SnmpEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseSnmpRecurse(zeekDir + givenDay + "/")
+	return
+}
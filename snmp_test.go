@@ -0,0 +1,29 @@
+package zeekparse
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThisLogEntryToSnmpStruct(t *testing.T) {
+	log.SetFormatter(&log.TextFormatter{ForceColors: true})
+	log.SetLevel(log.InfoLevel)
+
+	// compressed case
+	compressedResults, header, compErr := parseZeekLog("test_input/simple_snmp.log.gz")
+	for _, thisResult := range compressedResults {
+		_, parseErr := thisLogEntryToSnmpStruct(thisResult, header)
+		assert.NoError(t, parseErr)
+	}
+	assert.NoError(t, compErr)
+
+	allSnmp, err := ParseSnmpLog("test_input/simple_snmp.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(allSnmp))
+	assert.Equal(t, "public", allSnmp[0].Community)
+	assert.Equal(t, int64(1620000000), allSnmp[0].UpSince.Unix())
+	assert.False(t, allSnmp[1].Duration.IsSet())
+	assert.Equal(t, Some(2), allSnmp[1].SetRequests)
+	assert.True(t, allSnmp[1].UpSince.IsZero())
+}
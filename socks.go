@@ -0,0 +1,161 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"time"
+)
+
+// socks log format described in https://docs.zeek.org/en/master/scripts/base/protocols/socks/main.zeek.html#type-SOCKS::Info
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// SocksEntry is a fully parsed socks.log line.
+type SocksEntry struct {
+	TS      time.Time // TS:time - timestamp
+	Uid     string    // Uid:string - unique id
+	IdOrigH string    // id_orig_h:addr - senders address
+	IdOrigP int       // id_orig_p:addr - senders port
+	IdRespH string    // id_resp_h:port - responders address
+	IdRespP int       // id_resp_p:port - responders port
+	// ---------
+	Version     Opt[int] // version:count - protocol version of SOCKS
+	User        string   // user:string - username used to request a login to the proxy
+	Password    string   // password:string - password used to request a login to the proxy
+	Status      string   // status:string - server status for the attempt at using the proxy
+	RequestHost string   // request.host:addr - client requested SOCKS address if given as an address
+	RequestName string   // request.name:string - client requested SOCKS address if given as a name
+	RequestP    Opt[int] // request_p:port - client requested port
+	BoundHost   string   // bound.host:addr - server bound address if given as an address
+	BoundName   string   // bound.name:string - server bound address if given as a name
+	BoundP      Opt[int] // bound_p:port - server bound port
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (s *SocksEntry) Print() {
+	fmt.Printf("(%s) client {%s:%d} talks to {%s:%d}:\n",
+		s.TS.String(), s.IdOrigH, s.IdOrigP, s.IdRespH, s.IdRespP)
+	fmt.Printf("\tSOCKS%s %s%s:%s status:%s\n", s.Version, s.RequestHost, s.RequestName, s.RequestP, s.Status)
+}
+
+func (s *SocksEntry) ShortPrint() {
+	fmt.Printf("[%s] %s -> %s%s:%s\n", s.TS, s.IdOrigH, s.RequestHost, s.RequestName, s.RequestP)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToSocksStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (socksEntry SocksEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			socksEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "uid":
+			socksEntry.Uid = thisField.value
+		case "id.orig_h":
+			socksEntry.IdOrigH = thisField.value
+		case "id.orig_p":
+			socksEntry.IdOrigP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id.resp_h":
+			socksEntry.IdRespH = thisField.value
+		case "id.resp_p":
+			socksEntry.IdRespP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "version":
+			socksEntry.Version, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "user":
+			socksEntry.User = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "password":
+			socksEntry.Password = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "status":
+			socksEntry.Status = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "request.host":
+			socksEntry.RequestHost = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "request.name":
+			socksEntry.RequestName = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "request_p":
+			socksEntry.RequestP, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "bound.host":
+			socksEntry.BoundHost = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "bound.name":
+			socksEntry.BoundName = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "bound_p":
+			socksEntry.BoundP, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseSocksLog will parse through the given single socks log (passed as a filename string)
+func ParseSocksLog(givenFilename string) (parsedResults []SocksEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes SocksEntry
+		thisRes, err = thisLogEntryToSocksStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseSocksRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseSocksRecurse(givenDirectory string) (allResults []SocksEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "socks") {
+		thisResult, parseErr := ParseSocksLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllSocksForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed SocksEntry objects
+func GetAllSocksForDay(givenDay string, givenZeekDir ...string) (allRes []SocksEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseSocksRecurse(zeekDir + givenDay + "/")
+	return
+}
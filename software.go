@@ -0,0 +1,196 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"strings"
+	"time"
+)
+
+// software log format described in https://docs.zeek.org/en/master/scripts/base/frameworks/software/main.zeek.html#type-Software::Info
+
+// ------------------------------
+// ----- Helper Structures ------
+// ------------------------------
+
+// SoftwareVersion is a software version broken up the same way zeek does.
+type SoftwareVersion struct {
+	Major  Opt[int] // version.major:count - major version number
+	Minor  Opt[int] // version.minor:count - minor version number
+	Minor2 Opt[int] // version.minor2:count - minor subversion number
+	Minor3 Opt[int] // version.minor3:count - minor update number
+	Addl   string   // version.addl:string - additional version string (ie: "beta42")
+}
+
+// String returns the version in zeek's dotted form (ie: 8.2.1-p1).
+func (v SoftwareVersion) String() string {
+	var parts []string
+	for _, thisPart := range []Opt[int]{v.Major, v.Minor, v.Minor2, v.Minor3} {
+		if !thisPart.IsSet() {
+			break
+		}
+		parts = append(parts, thisPart.String())
+	}
+	versionStr := strings.Join(parts, ".")
+	if len(v.Addl) > 0 {
+		if len(versionStr) > 0 {
+			versionStr += "-"
+		}
+		versionStr += v.Addl
+	}
+	return versionStr
+}
+
+// Compare returns -1, 0 or 1 if this version is older, the same or newer than the given one.
+// Only the numeric parts are compared, unset parts are treated as older than any set value.
+func (v SoftwareVersion) Compare(givenVersion SoftwareVersion) int {
+	ours := []Opt[int]{v.Major, v.Minor, v.Minor2, v.Minor3}
+	theirs := []Opt[int]{givenVersion.Major, givenVersion.Minor, givenVersion.Minor2, givenVersion.Minor3}
+	for idx := range ours {
+		if ours[idx].IsSet() != theirs[idx].IsSet() {
+			if ours[idx].IsSet() {
+				return 1
+			}
+			return -1
+		}
+		if ours[idx].Value() < theirs[idx].Value() {
+			return -1
+		} else if ours[idx].Value() > theirs[idx].Value() {
+			return 1
+		}
+	}
+	return 0
+}
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// SoftwareEntry is a fully parsed software.log line.
+type SoftwareEntry struct {
+	TS time.Time // TS:time - timestamp
+	// ---------
+	Host            string          // host:addr - IP address detected running the software
+	HostP           Opt[int]        // host_p:port - port on which the software is running
+	SoftwareType    string          // software_type:enum - type of software detected (ie: HTTP::SERVER)
+	Name            string          // name:string - name of the software (ie: Apache)
+	Version         SoftwareVersion // version.*:count - version of the software
+	UnparsedVersion string          // unparsed_version:string - full unparsed version string found
+	URL             string          // url:string - root URL where the software was discovered
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (s *SoftwareEntry) Print() {
+	fmt.Printf("(%s) %s:%s runs %s %s %s\n", s.TS.String(), s.Host, s.HostP, s.SoftwareType, s.Name, s.Version.String())
+}
+
+func (s *SoftwareEntry) ShortPrint() {
+	fmt.Printf("[%s] %s %s %s\n", s.TS, s.Host, s.Name, s.Version.String())
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToSoftwareStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (softwareEntry SoftwareEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			softwareEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "host":
+			softwareEntry.Host = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "host_p":
+			softwareEntry.HostP, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "software_type":
+			softwareEntry.SoftwareType = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "name":
+			softwareEntry.Name = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "version.major":
+			softwareEntry.Version.Major, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "version.minor":
+			softwareEntry.Version.Minor, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "version.minor2":
+			softwareEntry.Version.Minor2, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "version.minor3":
+			softwareEntry.Version.Minor3, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "version.addl":
+			softwareEntry.Version.Addl = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "unparsed_version":
+			softwareEntry.UnparsedVersion = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "url":
+			softwareEntry.URL = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseSoftwareLog will parse through the given single software log (passed as a filename string)
+func ParseSoftwareLog(givenFilename string) (parsedResults []SoftwareEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes SoftwareEntry
+		thisRes, err = thisLogEntryToSoftwareStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseSoftwareRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseSoftwareRecurse(givenDirectory string) (allResults []SoftwareEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "software") {
+		thisResult, parseErr := ParseSoftwareLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllSoftwareForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed SoftwareEntry objects
+func GetAllSoftwareForDay(givenDay string, givenZeekDir ...string) (allRes []SoftwareEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseSoftwareRecurse(zeekDir + givenDay + "/")
+	return
+}
@@ -0,0 +1,35 @@
+package zeekparse
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThisLogEntryToSoftwareStruct(t *testing.T) {
+	log.SetFormatter(&log.TextFormatter{ForceColors: true})
+	log.SetLevel(log.InfoLevel)
+
+	// compressed case
+	compressedResults, header, compErr := parseZeekLog("test_input/simple_software.log.gz")
+	for _, thisResult := range compressedResults {
+		_, parseErr := thisLogEntryToSoftwareStruct(thisResult, header)
+		assert.NoError(t, parseErr)
+	}
+	assert.NoError(t, compErr)
+}
+
+func TestSoftwareVersion(t *testing.T) {
+	allSoftware, err := ParseSoftwareLog("test_input/simple_software.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(allSoftware))
+
+	assert.Equal(t, "7.9-p1", allSoftware[0].Version.String())
+	assert.False(t, allSoftware[0].Version.Minor2.IsSet())
+	assert.Equal(t, "88.0.4324.182", allSoftware[3].Version.String())
+	assert.False(t, allSoftware[3].HostP.IsSet())
+
+	assert.Equal(t, -1, allSoftware[1].Version.Compare(allSoftware[2].Version))
+	assert.Equal(t, 1, allSoftware[2].Version.Compare(allSoftware[1].Version))
+	assert.Equal(t, 0, allSoftware[1].Version.Compare(allSoftware[1].Version))
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	log "github.com/sirupsen/logrus"
+	"sort"
 	"strconv"
 	"time"
 )
@@ -27,12 +28,22 @@ type SSLEntry struct {
 	Curve string	  		// curve: string - ECDH/ECDHE curve that server chose
 	ServerName string 		// server_name: string - SNI value.
 	Resumed bool 			// resumed:bool - Flag to indicate if the session was resumed reusing the key material exchanged in an earlier connection.
+	LastAlert string		// last_alert:string - last alert that was seen during the connection.
+	NextProtocol string		// next_protocol:string - next protocol the server chose using the application layer next protocol extension (ALPN).
 	Established bool 		// established:bool - flag to indicate if successfully established or aborted mid-handshake.
+	SslHistory string		// ssl_history:string - handshake message history, uppercase letters for the client and lowercase for the server.
+	CertChainFuids []string			// cert_chain_fuids:vector[string] - file unique ids of the certificates offered by the server, in order.
+	ClientCertChainFuids []string	// client_cert_chain_fuids:vector[string] - file unique ids of the certificates offered by the client, in order.
+	CertChainFps []string			// cert_chain_fps:vector[string] - sha256 fingerprints of the certificates offered by the server, in order.
+	ClientCertChainFps []string		// client_cert_chain_fps:vector[string] - sha256 fingerprints of the certificates offered by the client, in order.
 	ServerSubject string	// subject: string - X509 subject if provided
 	ServerIssuer string		// issuer: string - Signer of the X509 if provided.
 	ClientSubject string 	// client_subject: string - clients x509 subject if provided.
 	ClientIssuer string	 	// client_issuer: string - clients x509 issuer if provided.
 	Validation string		// validation_status: string - result of validation status
+	SniMatchesCert bool		// sni_matches_cert:bool - set to true if the hostname sent in the SNI matches the certificate.
+	Ja3 string				// ja3:string - JA3 fingerprint of the client hello (from the ja3 package).
+	Ja3s string				// ja3s:string - JA3S fingerprint of the server hello (from the ja3 package).
 }
 
 // ------------------------------
@@ -51,6 +62,74 @@ func (s *SSLEntry) ShortPrint() {
 		s.ServerName)
 }
 
+// ------------------------------
+// ----    Entry Helpers   ------
+// ------------------------------
+
+// GroupByJa3 returns the sorted unique client addresses seen with each JA3 fingerprint.
+// Entries without a JA3 value are skipped.
+func GroupByJa3(givenSSL []SSLEntry) map[string][]string {
+	clientsByJa3 := make(map[string][]string)
+	for _, thisSSL := range givenSSL {
+		if len(thisSSL.Ja3) == 0 {
+			continue
+		}
+		clientsByJa3[thisSSL.Ja3] = appendUnique(clientsByJa3[thisSSL.Ja3], thisSSL.IdOrigH)
+	}
+	for _, thisClients := range clientsByJa3 {
+		sort.Strings(thisClients)
+	}
+	return clientsByJa3
+}
+
+// ServerSubjectDN returns the parsed subject of the server certificate.
+func (s *SSLEntry) ServerSubjectDN() (DistinguishedName, error) {
+	return ParseDN(s.ServerSubject)
+}
+
+// ServerIssuerDN returns the parsed issuer of the server certificate.
+func (s *SSLEntry) ServerIssuerDN() (DistinguishedName, error) {
+	return ParseDN(s.ServerIssuer)
+}
+
+// ClientSubjectDN returns the parsed subject of the client certificate.
+func (s *SSLEntry) ClientSubjectDN() (DistinguishedName, error) {
+	return ParseDN(s.ClientSubject)
+}
+
+// ClientIssuerDN returns the parsed issuer of the client certificate.
+func (s *SSLEntry) ClientIssuerDN() (DistinguishedName, error) {
+	return ParseDN(s.ClientIssuer)
+}
+
+// ServerCommonName returns the CN of the server certificate subject, blank if it has none.
+func (s *SSLEntry) ServerCommonName() string {
+	return commonNameOf(s.ServerSubject)
+}
+
+// ServerSelfSigned tells if the server certificate subject and issuer are the same name.
+func (s *SSLEntry) ServerSelfSigned() bool {
+	return len(s.ServerSubject) > 0 && sameDN(s.ServerSubject, s.ServerIssuer)
+}
+
+// TLSVersion returns the typed version the server chose, TLSVersionUnknown if none was logged.
+func (s *SSLEntry) TLSVersion() TLSVersion {
+	version, _ := ParseTLSVersion(s.Version)
+	return version
+}
+
+// CipherSuite returns the classified cipher suite the server chose.
+func (s *SSLEntry) CipherSuite() CipherSuite {
+	suite, _ := ClassifyCipher(s.Cipher)
+	return suite
+}
+
+// WeakCrypto tells if the session used a version older than TLS 1.2 or a weak or insecure
+// cipher suite.  Sessions that never logged a version or cipher are not counted as weak.
+func (s *SSLEntry) WeakCrypto() bool {
+	return s.TLSVersion().IsDeprecated() || s.CipherSuite().Verdict >= CipherWeak
+}
+
 // ------------------------------
 // ---- Main Parse Function -----
 // ------------------------------
@@ -96,8 +175,22 @@ func thisLogEntryToSSLStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFi
 			SSLEntry.ServerName = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
 		case "resumed":
 			SSLEntry.Resumed = thisField.value == "T"
+		case "last_alert":
+			SSLEntry.LastAlert = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "next_protocol":
+			SSLEntry.NextProtocol = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
 		case "established":
 			SSLEntry.Established = thisField.value == "T"
+		case "ssl_history":
+			SSLEntry.SslHistory = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "cert_chain_fuids":
+			SSLEntry.CertChainFuids = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "client_cert_chain_fuids":
+			SSLEntry.ClientCertChainFuids = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "cert_chain_fps":
+			SSLEntry.CertChainFps = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "client_cert_chain_fps":
+			SSLEntry.ClientCertChainFps = strSliceNilIfUnset(thisField.value, givenLogOpts)
 		case "subject":
 			SSLEntry.ServerSubject = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
 		case "issuer":
@@ -106,8 +199,14 @@ func thisLogEntryToSSLStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFi
 			SSLEntry.ClientSubject = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
 		case "client_issuer":
 			SSLEntry.ClientIssuer = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
-		case "validation":
+		case "validation_status", "validation":
 			SSLEntry.Validation = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "sni_matches_cert":
+			SSLEntry.SniMatchesCert = thisField.value == "T"
+		case "ja3":
+			SSLEntry.Ja3 = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "ja3s":
+			SSLEntry.Ja3s = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
 		}
 	}
 	return
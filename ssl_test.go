@@ -19,3 +19,40 @@ func TestThisLogEntryToSSLStruct(t *testing.T) {
 	assert.NoError(t, compErr)
 }
 
+func TestParseSSLLogValidationStatus(t *testing.T) {
+	allSSL, err := ParseSSLLog("test_input/simple_ssl.log")
+	assert.NoError(t, err)
+	assert.Equal(t, "CT9hMz3HHe1OtWjft4", allSSL[3].Uid)
+	assert.Equal(t, "ok", allSSL[3].Validation)
+	assert.Equal(t, "", allSSL[0].Validation)
+}
+
+func TestParseSSLLogFullSchema(t *testing.T) {
+	allSSL, err := ParseSSLLog("test_input/simple_ssl_full.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(allSSL))
+
+	assert.Equal(t, "h2", allSSL[0].NextProtocol)
+	assert.Equal(t, "CsxknGIti", allSSL[0].SslHistory)
+	assert.Equal(t, []string{"FSsf1a2b3c4d5e6f7g", "FSsf2b3c4d5e6f7g1a"}, allSSL[0].CertChainFuids)
+	assert.Equal(t, 2, len(allSSL[0].CertChainFps))
+	assert.Nil(t, allSSL[0].ClientCertChainFuids)
+	assert.True(t, allSSL[0].SniMatchesCert)
+	assert.Equal(t, "ok", allSSL[0].Validation)
+	assert.Equal(t, "773906b0efdefa24a7f2b8eb6985bf37", allSSL[0].Ja3)
+	assert.Equal(t, "f4febc55ea12b31ae17cfb7e614afda8", allSSL[0].Ja3s)
+
+	assert.Equal(t, "unknown_ca", allSSL[1].LastAlert)
+	assert.False(t, allSSL[1].Established)
+	assert.Equal(t, "unable to get local issuer certificate", allSSL[1].Validation)
+	assert.Equal(t, "CN=laptop-17", allSSL[1].ClientSubject)
+	assert.Equal(t, "", allSSL[1].Ja3s)
+
+	assert.True(t, allSSL[2].Resumed)
+	assert.Equal(t, "", allSSL[2].Validation)
+	assert.Nil(t, allSSL[2].CertChainFuids)
+
+	clientsByJa3 := GroupByJa3(allSSL)
+	assert.Equal(t, 1, len(clientsByJa3))
+	assert.Equal(t, []string{"192.168.1.110", "192.168.1.111"}, clientsByJa3["773906b0efdefa24a7f2b8eb6985bf37"])
+}
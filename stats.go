@@ -0,0 +1,245 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+// stats log format described in https://docs.zeek.org/en/master/scripts/policy/misc/stats.zeek.html#type-Stats::Info
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// StatsEntry is a fully parsed stats.log line.
+type StatsEntry struct {
+	TS time.Time // TS:time - timestamp
+	// ---------
+	Peer               string       // peer:string - peer that generated this log
+	Mem                Opt[int]     // mem:count - amount of memory currently in use in MB
+	PktsProc           Opt[int]     // pkts_proc:count - number of packets processed since the last stats interval
+	BytesRecv          Opt[int]     // bytes_recv:count - number of bytes received since the last stats interval if reading live traffic
+	PktsDropped        Opt[int]     // pkts_dropped:count - number of packets dropped since the last stats interval if reading live traffic
+	PktsLink           Opt[int]     // pkts_link:count - number of packets seen on the link since the last stats interval if reading live traffic
+	PktLag             Opt[float64] // pkt_lag:interval - lag between the wall clock and packet timestamps if reading live traffic
+	EventsProc         Opt[int]     // events_proc:count - number of events processed since the last stats interval
+	EventsQueued       Opt[int]     // events_queued:count - number of events that have been queued since the last stats interval
+	ActiveTcpConns     Opt[int]     // active_tcp_conns:count - TCP connections currently in memory
+	ActiveUdpConns     Opt[int]     // active_udp_conns:count - UDP connections currently in memory
+	ActiveIcmpConns    Opt[int]     // active_icmp_conns:count - ICMP connections currently in memory
+	TcpConns           Opt[int]     // tcp_conns:count - TCP connections seen since last stats interval
+	UdpConns           Opt[int]     // udp_conns:count - UDP connections seen since last stats interval
+	IcmpConns          Opt[int]     // icmp_conns:count - ICMP connections seen since last stats interval
+	Timers             Opt[int]     // timers:count - number of timers scheduled since last stats interval
+	ActiveTimers       Opt[int]     // active_timers:count - current number of scheduled timers
+	Files              Opt[int]     // files:count - number of files seen since last stats interval
+	ActiveFiles        Opt[int]     // active_files:count - current number of files actively being seen
+	DnsRequests        Opt[int]     // dns_requests:count - number of DNS requests seen since last stats interval
+	ActiveDnsRequests  Opt[int]     // active_dns_requests:count - current number of DNS requests awaiting a reply
+	ReassemTcpSize     Opt[int]     // reassem_tcp_size:count - current size of TCP data in reassembly
+	ReassemFileSize    Opt[int]     // reassem_file_size:count - current size of File data in reassembly
+	ReassemFragSize    Opt[int]     // reassem_frag_size:count - current size of packet fragment data in reassembly
+	ReassemUnknownSize Opt[int]     // reassem_unknown_size:count - current size of unknown data in reassembly
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (s *StatsEntry) Print() {
+	fmt.Printf("(%s) %s processed %s pkts, dropped %s of %s on link, mem %sMB\n", s.TS.String(), s.Peer, s.PktsProc, s.PktsDropped, s.PktsLink, s.Mem)
+}
+
+func (s *StatsEntry) ShortPrint() {
+	fmt.Printf("[%s] %s pkts:%s dropped:%s\n", s.TS, s.Peer, s.PktsProc, s.PktsDropped)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToStatsStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (statsEntry StatsEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			statsEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "peer":
+			statsEntry.Peer = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "mem":
+			statsEntry.Mem, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "pkts_proc":
+			statsEntry.PktsProc, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "bytes_recv":
+			statsEntry.BytesRecv, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "pkts_dropped":
+			statsEntry.PktsDropped, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "pkts_link":
+			statsEntry.PktsLink, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "pkt_lag":
+			statsEntry.PktLag, err = optFloat(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "events_proc":
+			statsEntry.EventsProc, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "events_queued":
+			statsEntry.EventsQueued, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "active_tcp_conns":
+			statsEntry.ActiveTcpConns, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "active_udp_conns":
+			statsEntry.ActiveUdpConns, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "active_icmp_conns":
+			statsEntry.ActiveIcmpConns, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "tcp_conns":
+			statsEntry.TcpConns, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "udp_conns":
+			statsEntry.UdpConns, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "icmp_conns":
+			statsEntry.IcmpConns, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "timers":
+			statsEntry.Timers, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "active_timers":
+			statsEntry.ActiveTimers, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "files":
+			statsEntry.Files, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "active_files":
+			statsEntry.ActiveFiles, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "dns_requests":
+			statsEntry.DnsRequests, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "active_dns_requests":
+			statsEntry.ActiveDnsRequests, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "reassem_tcp_size":
+			statsEntry.ReassemTcpSize, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "reassem_file_size":
+			statsEntry.ReassemFileSize, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "reassem_frag_size":
+			statsEntry.ReassemFragSize, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "reassem_unknown_size":
+			statsEntry.ReassemUnknownSize, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseStatsLog will parse through the given single stats log (passed as a filename string)
+func ParseStatsLog(givenFilename string) (parsedResults []StatsEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes StatsEntry
+		thisRes, err = thisLogEntryToStatsStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseStatsRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseStatsRecurse(givenDirectory string) (allResults []StatsEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "stats") {
+		thisResult, parseErr := ParseStatsLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllStatsForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed StatsEntry objects
+func GetAllStatsForDay(givenDay string, givenZeekDir ...string) (allRes []StatsEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseStatsRecurse(zeekDir + givenDay + "/")
+	return
+}
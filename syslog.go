@@ -0,0 +1,134 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"time"
+)
+
+// syslog log format described in https://docs.zeek.org/en/master/scripts/base/protocols/syslog/main.zeek.html#type-Syslog::Info
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// SyslogEntry is a fully parsed syslog.log line.
+type SyslogEntry struct {
+	TS      time.Time // TS:time - timestamp
+	Uid     string    // Uid:string - unique id
+	IdOrigH string    // id_orig_h:addr - senders address
+	IdOrigP int       // id_orig_p:addr - senders port
+	IdRespH string    // id_resp_h:port - responders address
+	IdRespP int       // id_resp_p:port - responders port
+	// ---------
+	Proto    Proto  // proto:enum - protocol over which the message was seen
+	Facility string // facility:string - syslog facility for the message
+	Severity string // severity:string - syslog severity for the message
+	Message  string // message:string - the plain text message
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (s *SyslogEntry) Print() {
+	fmt.Printf("(%s) client {%s:%d} talks to {%s:%d}:\n",
+		s.TS.String(), s.IdOrigH, s.IdOrigP, s.IdRespH, s.IdRespP)
+	fmt.Printf("\t%s.%s %s\n", s.Facility, s.Severity, s.Message)
+}
+
+func (s *SyslogEntry) ShortPrint() {
+	fmt.Printf("[%s] %s %s.%s %s\n", s.TS, s.IdOrigH, s.Facility, s.Severity, s.Message)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToSyslogStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (syslogEntry SyslogEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			syslogEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "uid":
+			syslogEntry.Uid = thisField.value
+		case "id.orig_h":
+			syslogEntry.IdOrigH = thisField.value
+		case "id.orig_p":
+			syslogEntry.IdOrigP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id.resp_h":
+			syslogEntry.IdRespH = thisField.value
+		case "id.resp_p":
+			syslogEntry.IdRespP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "proto":
+			syslogEntry.Proto = ParseProto(thisField.value)
+		case "facility":
+			syslogEntry.Facility = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "severity":
+			syslogEntry.Severity = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "message":
+			syslogEntry.Message = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseSyslogLog will parse through the given single syslog log (passed as a filename string)
+func ParseSyslogLog(givenFilename string) (parsedResults []SyslogEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes SyslogEntry
+		thisRes, err = thisLogEntryToSyslogStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseSyslogRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseSyslogRecurse(givenDirectory string) (allResults []SyslogEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "syslog") {
+		thisResult, parseErr := ParseSyslogLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllSyslogForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed SyslogEntry objects
+func GetAllSyslogForDay(givenDay string, givenZeekDir ...string) (allRes []SyslogEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseSyslogRecurse(zeekDir + givenDay + "/")
+	return
+}
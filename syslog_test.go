@@ -0,0 +1,28 @@
+package zeekparse
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThisLogEntryToSyslogStruct(t *testing.T) {
+	log.SetFormatter(&log.TextFormatter{ForceColors: true})
+	log.SetLevel(log.InfoLevel)
+
+	// compressed case
+	compressedResults, header, compErr := parseZeekLog("test_input/simple_syslog.log.gz")
+	for _, thisResult := range compressedResults {
+		_, parseErr := thisLogEntryToSyslogStruct(thisResult, header)
+		assert.NoError(t, parseErr)
+	}
+	assert.NoError(t, compErr)
+
+	allSyslog, err := ParseSyslogLog("test_input/simple_syslog.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(allSyslog))
+	assert.Equal(t, UDP, allSyslog[0].Proto)
+	assert.Equal(t, "LOCAL7", allSyslog[0].Facility)
+	assert.Equal(t, TCP, allSyslog[1].Proto)
+	assert.Equal(t, "WARNING", allSyslog[1].Severity)
+}
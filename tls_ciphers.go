@@ -0,0 +1,198 @@
+/*
+Cipher suite classification.  zeek logs the IANA name of the suite the server chose (ie:
+TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256), ClassifyCipher breaks that name into its key
+exchange, authentication, bulk cipher and MAC and gives a verdict on the suite as a whole.
+*/
+
+package zeekparse
+
+import (
+	"strings"
+)
+
+// CipherVerdict is how safe a cipher suite is to use, ordered from best to worst.
+type CipherVerdict int
+
+const (
+	CipherUnknown  CipherVerdict = iota // not a suite name we could break down
+	CipherSecure                        // forward secret key exchange with an AEAD cipher
+	CipherWeak                          // still works but should be phased out (ie: no forward secrecy, CBC or SHA1)
+	CipherInsecure                      // broken, do not use (ie: RC4, DES, export or anonymous suites)
+)
+
+func (c CipherVerdict) String() string {
+	switch c {
+	case CipherSecure:
+		return "secure"
+	case CipherWeak:
+		return "weak"
+	case CipherInsecure:
+		return "insecure"
+	}
+	return "unknown"
+}
+
+// CipherSuite is a cipher suite broken into its parts.
+type CipherSuite struct {
+	Name        string        // IANA name as logged
+	KeyExchange string        // ie: ECDHE, DHE, RSA, or ANY for TLS 1.3 suites
+	Auth        string        // ie: RSA, ECDSA, anon, or ANY for TLS 1.3 suites
+	Bulk        string        // ie: AES_128_GCM, CHACHA20_POLY1305, 3DES_EDE_CBC
+	MAC         string        // ie: SHA, SHA256, or AEAD when the bulk cipher authenticates itself
+	Export      bool          // deliberately weakened export suite
+	Verdict     CipherVerdict // worst verdict of all the parts
+	Reasons     []string      // why the verdict is not secure
+}
+
+// cipherPartVerdict is the verdict for a single part of a suite and why.
+type cipherPartVerdict struct {
+	verdict CipherVerdict
+	reason  string
+}
+
+var cipherKeyExchanges = map[string]cipherPartVerdict{
+	"ECDHE": {CipherSecure, ""},
+	"DHE":   {CipherSecure, ""},
+	"ANY":   {CipherSecure, ""},
+	"RSA":   {CipherWeak, "no forward secrecy"},
+	"DH":    {CipherWeak, "no forward secrecy"},
+	"ECDH":  {CipherWeak, "no forward secrecy"},
+	"PSK":   {CipherWeak, "no forward secrecy"},
+	"SRP":   {CipherWeak, "no forward secrecy"},
+	"KRB5":  {CipherWeak, "no forward secrecy"},
+	"NULL":  {CipherInsecure, "no key exchange"},
+}
+
+var cipherAuths = map[string]cipherPartVerdict{
+	"anon": {CipherInsecure, "anonymous (unauthenticated) key exchange"},
+	"NULL": {CipherInsecure, "no authentication"},
+	"DSS":  {CipherWeak, "DSA authentication"},
+}
+
+// cipherBulks are checked in order against the start of the bulk cipher so the longer names
+// must come first.
+var cipherBulks = []struct {
+	prefix string
+	cipherPartVerdict
+}{
+	{"NULL", cipherPartVerdict{CipherInsecure, "no encryption"}},
+	{"RC4", cipherPartVerdict{CipherInsecure, "RC4"}},
+	{"RC2", cipherPartVerdict{CipherInsecure, "RC2"}},
+	{"DES40", cipherPartVerdict{CipherInsecure, "40 bit DES"}},
+	{"DES_CBC", cipherPartVerdict{CipherInsecure, "single DES"}},
+	{"IDEA", cipherPartVerdict{CipherInsecure, "IDEA"}},
+	{"3DES", cipherPartVerdict{CipherWeak, "3DES (64 bit block)"}},
+	{"CHACHA20_POLY1305", cipherPartVerdict{CipherSecure, ""}},
+	{"AES", cipherPartVerdict{CipherSecure, ""}},
+	{"CAMELLIA", cipherPartVerdict{CipherSecure, ""}},
+	{"ARIA", cipherPartVerdict{CipherSecure, ""}},
+	{"SEED", cipherPartVerdict{CipherWeak, "SEED"}},
+}
+
+var cipherMACs = map[string]cipherPartVerdict{
+	"AEAD":   {CipherSecure, ""},
+	"SHA256": {CipherSecure, ""},
+	"SHA384": {CipherSecure, ""},
+	"SHA":    {CipherWeak, "SHA1 MAC"},
+	"MD5":    {CipherInsecure, "MD5 MAC"},
+	"NULL":   {CipherInsecure, "no MAC"},
+}
+
+// cipherHashes are the hashes that may end a suite name.
+var cipherHashes = map[string]bool{"SHA": true, "SHA256": true, "SHA384": true, "MD5": true, "NULL": true, "SM3": true}
+
+// add records the given part verdict against the suite.
+func (c *CipherSuite) add(givenPart cipherPartVerdict) {
+	if givenPart.verdict > c.Verdict {
+		c.Verdict = givenPart.verdict
+	}
+	if len(givenPart.reason) > 0 {
+		c.Reasons = appendUnique(c.Reasons, givenPart.reason)
+	}
+}
+
+// ClassifyCipher breaks the given IANA cipher suite name into its parts and gives a verdict.
+// ok is false if the name isn't in the TLS_<kx>_<auth>_WITH_<bulk>_<mac> or TLS 1.3
+// TLS_<bulk>_<hash> form, the returned suite then has only its Name set.
+func ClassifyCipher(givenName string) (suite CipherSuite, ok bool) {
+	suite.Name = givenName
+	if strings.HasPrefix(givenName, "SSLv20_") {
+		suite.KeyExchange, suite.Auth = "RSA", "RSA"
+		suite.Verdict = CipherInsecure
+		suite.Reasons = []string{"SSLv2 suite"}
+		return suite, true
+	}
+	rest := strings.TrimPrefix(strings.TrimPrefix(givenName, "TLS_"), "SSL_")
+	if rest == givenName || len(rest) == 0 {
+		return
+	}
+
+	var bulkAndMac string
+	if idx := strings.Index(rest, "_WITH_"); idx >= 0 {
+		kxParts := strings.Split(rest[:idx], "_")
+		bulkAndMac = rest[idx+len("_WITH_"):]
+		suite.KeyExchange = kxParts[0]
+		var authParts []string
+		for _, thisPart := range kxParts[1:] {
+			if strings.HasPrefix(thisPart, "EXPORT") {
+				suite.Export = true
+			} else if thisPart != "SHA" {
+				authParts = append(authParts, thisPart)
+			}
+		}
+		suite.Auth = strings.Join(authParts, "_")
+		if len(suite.Auth) == 0 {
+			suite.Auth = suite.KeyExchange
+		}
+	} else {
+		// TLS 1.3 suites only name the cipher and hash, key exchange and auth are negotiated separately
+		suite.KeyExchange, suite.Auth = "ANY", "ANY"
+		bulkAndMac = rest
+	}
+
+	bulkParts := strings.Split(bulkAndMac, "_")
+	if len(bulkParts) > 1 && cipherHashes[bulkParts[len(bulkParts)-1]] {
+		suite.MAC = bulkParts[len(bulkParts)-1]
+		bulkParts = bulkParts[:len(bulkParts)-1]
+	}
+	suite.Bulk = strings.Join(bulkParts, "_")
+	if strings.Contains(suite.Bulk, "GCM") || strings.Contains(suite.Bulk, "CCM") || strings.Contains(suite.Bulk, "POLY1305") {
+		suite.MAC = "AEAD"
+	}
+	if len(suite.Bulk) == 0 || len(suite.MAC) == 0 {
+		return CipherSuite{Name: givenName}, false
+	}
+
+	suite.Verdict = CipherSecure
+	if part, found := cipherKeyExchanges[suite.KeyExchange]; found {
+		suite.add(part)
+	} else {
+		suite.add(cipherPartVerdict{CipherWeak, "unrecognised key exchange " + suite.KeyExchange})
+	}
+	for _, thisAuth := range strings.Split(suite.Auth, "_") {
+		if part, found := cipherAuths[thisAuth]; found {
+			suite.add(part)
+		}
+	}
+	if suite.Export {
+		suite.add(cipherPartVerdict{CipherInsecure, "export grade"})
+	}
+	bulkKnown := false
+	for _, thisBulk := range cipherBulks {
+		if strings.HasPrefix(suite.Bulk, thisBulk.prefix) {
+			suite.add(thisBulk.cipherPartVerdict)
+			bulkKnown = true
+			break
+		}
+	}
+	if !bulkKnown {
+		suite.add(cipherPartVerdict{CipherWeak, "unrecognised cipher " + suite.Bulk})
+	}
+	if strings.Contains(suite.Bulk, "CBC") {
+		suite.add(cipherPartVerdict{CipherWeak, "CBC mode"})
+	}
+	if part, found := cipherMACs[suite.MAC]; found {
+		suite.add(part)
+	}
+	return suite, true
+}
@@ -0,0 +1,79 @@
+package zeekparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestClassifyCipher(t *testing.T) {
+	suite, ok := ClassifyCipher("TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256")
+	assert.True(t, ok)
+	assert.Equal(t, CipherSuite{
+		Name:        "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+		KeyExchange: "ECDHE",
+		Auth:        "RSA",
+		Bulk:        "AES_128_GCM",
+		MAC:         "AEAD",
+		Verdict:     CipherSecure,
+	}, suite)
+
+	suite, ok = ClassifyCipher("TLS_AES_256_GCM_SHA384")
+	assert.True(t, ok)
+	assert.Equal(t, "ANY", suite.KeyExchange)
+	assert.Equal(t, CipherSecure, suite.Verdict)
+
+	suite, ok = ClassifyCipher("TLS_RSA_WITH_AES_128_CBC_SHA")
+	assert.True(t, ok)
+	assert.Equal(t, "RSA", suite.Auth)
+	assert.Equal(t, "SHA", suite.MAC)
+	assert.Equal(t, CipherWeak, suite.Verdict)
+	assert.Equal(t, []string{"no forward secrecy", "CBC mode", "SHA1 MAC"}, suite.Reasons)
+
+	suite, ok = ClassifyCipher("TLS_DHE_RSA_EXPORT_WITH_DES40_CBC_SHA")
+	assert.True(t, ok)
+	assert.True(t, suite.Export)
+	assert.Equal(t, "RSA", suite.Auth)
+	assert.Equal(t, CipherInsecure, suite.Verdict)
+
+	suite, ok = ClassifyCipher("TLS_DH_anon_WITH_AES_256_GCM_SHA384")
+	assert.True(t, ok)
+	assert.Equal(t, CipherInsecure, suite.Verdict)
+
+	suite, ok = ClassifyCipher("TLS_RSA_WITH_RC4_128_MD5")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"no forward secrecy", "RC4", "MD5 MAC"}, suite.Reasons)
+
+	suite, ok = ClassifyCipher("SSLv20_CK_RC4_128_WITH_MD5")
+	assert.True(t, ok)
+	assert.Equal(t, CipherInsecure, suite.Verdict)
+
+	for _, thisName := range []string{"", "-", "TLS_FALLBACK_SCSV", "TLS_EMPTY_RENEGOTIATION_INFO_SCSV", "unknown-65535"} {
+		suite, ok = ClassifyCipher(thisName)
+		assert.False(t, ok, thisName)
+		assert.Equal(t, CipherUnknown, suite.Verdict, thisName)
+	}
+	assert.Equal(t, "insecure", CipherInsecure.String())
+}
+
+func TestSSLEntryCrypto(t *testing.T) {
+	allSSL, err := ParseSSLLog("test_input/simple_ssl_weak.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, len(allSSL))
+
+	assert.Equal(t, TLSv10, allSSL[0].TLSVersion())
+	assert.Equal(t, "3DES_EDE_CBC", allSSL[0].CipherSuite().Bulk)
+	assert.True(t, allSSL[0].WeakCrypto())
+	assert.Equal(t, CipherInsecure, allSSL[1].CipherSuite().Verdict)
+	assert.True(t, allSSL[2].WeakCrypto())
+	assert.False(t, allSSL[3].WeakCrypto())
+	assert.Equal(t, TLSv13, allSSL[4].TLSVersion())
+	assert.False(t, allSSL[4].WeakCrypto())
+
+	var older []string
+	for _, thisSSL := range allSSL {
+		if thisSSL.TLSVersion().OlderThan(TLSv12) {
+			older = append(older, thisSSL.ServerName)
+		}
+	}
+	assert.Equal(t, []string{"legacy.example.com", "old.example.com"}, older)
+}
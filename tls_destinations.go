@@ -0,0 +1,99 @@
+/*
+Merges the server names seen in ssl.log and quic.log into a single view of TLS destinations.
+Browsers increasingly reach sites over QUIC so ssl.log alone undercounts where clients go.
+*/
+
+package zeekparse
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TLSDestination is every connection seen to a single server name over TLS or QUIC.
+type TLSDestination struct {
+	ServerName string    // lowercased SNI value
+	Servers    []string  // responder addresses seen serving the name
+	Clients    []string  // originator addresses that connected to the name
+	SSLCount   int       // number of ssl.log connections to the name that weren't over QUIC
+	QuicCount  int       // number of quic.log connections to the name
+	HTTP3Count int       // number of quic.log connections to the name that offered HTTP/3
+	FirstSeen  time.Time // earliest connection seen
+	LastSeen   time.Time // latest connection seen
+}
+
+func (d *TLSDestination) Print() {
+	fmt.Printf("%s (%s--%s) ssl:%d quic:%d h3:%d\n", d.ServerName,
+		d.FirstSeen.Format("01/02/06"), d.LastSeen.Format("01/02/06"), d.SSLCount, d.QuicCount, d.HTTP3Count)
+	fmt.Printf("\tservers:%s clients:%s\n", d.Servers, d.Clients)
+}
+
+// BuildTLSDestinations merges the server names from the given ssl and quic entries into one
+// TLSDestination per lowercased name, sorted by name.  Entries without a server name are skipped.
+// zeek 6.1 and later also write an ssl.log row for each QUIC connection with the same uid as its
+// quic.log row, those are only counted as QUIC.
+func BuildTLSDestinations(givenSSL []SSLEntry, givenQuic []QuicEntry) (allDest []*TLSDestination) {
+	byName := make(map[string]*TLSDestination)
+	quicUids := make(map[string]bool)
+	for _, thisQuic := range givenQuic {
+		quicUids[thisQuic.Uid] = true
+	}
+
+	getDest := func(givenName, givenOrig, givenResp string, givenTS time.Time) *TLSDestination {
+		name := strings.ToLower(givenName)
+		d, ok := byName[name]
+		if !ok {
+			d = &TLSDestination{ServerName: name}
+			byName[name] = d
+			allDest = append(allDest, d)
+		}
+		d.Clients = appendUnique(d.Clients, givenOrig)
+		d.Servers = appendUnique(d.Servers, givenResp)
+		updateSeen(&d.FirstSeen, &d.LastSeen, givenTS)
+		return d
+	}
+
+	for _, thisSSL := range givenSSL {
+		if len(thisSSL.ServerName) == 0 || quicUids[thisSSL.Uid] {
+			continue
+		}
+		getDest(thisSSL.ServerName, thisSSL.IdOrigH, thisSSL.IdRespH, thisSSL.TS).SSLCount++
+	}
+
+	for _, thisQuic := range givenQuic {
+		if len(thisQuic.ServerName) == 0 {
+			continue
+		}
+		d := getDest(thisQuic.ServerName, thisQuic.IdOrigH, thisQuic.IdRespH, thisQuic.TS)
+		d.QuicCount++
+		if thisQuic.IsHTTP3() {
+			d.HTTP3Count++
+		}
+	}
+
+	for _, thisDest := range allDest {
+		sort.Strings(thisDest.Servers)
+		sort.Strings(thisDest.Clients)
+	}
+	sort.Slice(allDest, func(i, j int) bool {
+		return allDest[i].ServerName < allDest[j].ServerName
+	})
+	return
+}
+
+// GetTLSDestinationsForDay parses the ssl and quic logs on the given day from the default zeek
+// directory and returns the merged TLS destinations.
+func GetTLSDestinationsForDay(givenDay string, givenZeekDir ...string) (allDest []*TLSDestination, err error) {
+	allSSL, err := GetAllSSLForDay(givenDay, givenZeekDir...)
+	if err != nil {
+		return
+	}
+	allQuic, err := GetAllQuicForDay(givenDay, givenZeekDir...)
+	if err != nil {
+		return
+	}
+	allDest = BuildTLSDestinations(allSSL, allQuic)
+	return
+}
@@ -0,0 +1,59 @@
+package zeekparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBuildTLSDestinations(t *testing.T) {
+	allSSL, err := ParseSSLLog("test_input/simple_ssl.log")
+	assert.NoError(t, err)
+	allQuic, err := ParseQuicLog("test_input/simple_quic.log")
+	assert.NoError(t, err)
+
+	allDest := BuildTLSDestinations(allSSL, allQuic)
+	assert.Equal(t, 9, len(allDest))
+	assert.Equal(t, "alive.github.com", allDest[0].ServerName)
+	assert.Equal(t, "www.youtube.com", allDest[8].ServerName)
+
+	// name seen over both ssl and quic
+	docs := allDest[3]
+	assert.Equal(t, "docs.google.com", docs.ServerName)
+	assert.Equal(t, 1, docs.SSLCount)
+	assert.Equal(t, 1, docs.QuicCount)
+	assert.Equal(t, 1, docs.HTTP3Count)
+	assert.Equal(t, []string{"172.217.0.238"}, docs.Servers)
+
+	// quic only and mixed case
+	youtube := allDest[8]
+	assert.Equal(t, 0, youtube.SSLCount)
+	assert.Equal(t, 2, youtube.QuicCount)
+	assert.Equal(t, []string{"192.168.1.110", "192.168.1.111"}, youtube.Clients)
+	assert.True(t, youtube.FirstSeen.Before(youtube.LastSeen))
+
+	assert.Equal(t, 0, len(BuildTLSDestinations(nil, nil)))
+}
+
+func TestBuildTLSDestinationsSharedUid(t *testing.T) {
+	// newer zeek writes an ssl.log row for each quic connection using the same uid
+	allSSL, err := ParseSSLLog("test_input/simple_ssl_quic.log")
+	assert.NoError(t, err)
+	allQuic, err := ParseQuicLog("test_input/simple_quic.log")
+	assert.NoError(t, err)
+
+	allDest := BuildTLSDestinations(allSSL, allQuic)
+	assert.Equal(t, 2, len(allDest))
+	docs := allDest[0]
+	assert.Equal(t, "docs.google.com", docs.ServerName)
+	assert.Equal(t, 1, docs.SSLCount)
+	assert.Equal(t, 1, docs.QuicCount)
+	youtube := allDest[1]
+	assert.Equal(t, "www.youtube.com", youtube.ServerName)
+	assert.Equal(t, 0, youtube.SSLCount)
+	assert.Equal(t, 2, youtube.QuicCount)
+
+	// without the quic log the ssl rows are all counted
+	allDest = BuildTLSDestinations(allSSL, nil)
+	assert.Equal(t, 2, allDest[0].SSLCount)
+	assert.Equal(t, 1, allDest[1].SSLCount)
+}
@@ -0,0 +1,121 @@
+/*
+Typed SSL/TLS versions.  ssl.log records the version as zeek's name for it (ie: TLSv12),
+TLSVersion gives those names an order so "older than TLS 1.2" is a comparison.
+*/
+
+package zeekparse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TLSVersion is an SSL, TLS or DTLS protocol version as its value on the wire.
+type TLSVersion uint16
+
+const (
+	TLSVersionUnknown TLSVersion = 0
+	SSLv2             TLSVersion = 0x0002
+	SSLv3             TLSVersion = 0x0300
+	TLSv10            TLSVersion = 0x0301
+	TLSv11            TLSVersion = 0x0302
+	TLSv12            TLSVersion = 0x0303
+	TLSv13            TLSVersion = 0x0304
+	DTLSv10           TLSVersion = 0xfeff
+	DTLSv12           TLSVersion = 0xfefd
+	DTLSv13           TLSVersion = 0xfefc
+)
+
+// tlsVersionNames are the names zeek logs in ssl.log's version field.
+var tlsVersionNames = map[TLSVersion]string{
+	SSLv2:   "SSLv2",
+	SSLv3:   "SSLv3",
+	TLSv10:  "TLSv10",
+	TLSv11:  "TLSv11",
+	TLSv12:  "TLSv12",
+	TLSv13:  "TLSv13",
+	DTLSv10: "DTLSv10",
+	DTLSv12: "DTLSv12",
+	DTLSv13: "DTLSv13",
+}
+
+// tlsVersionRanks orders the versions oldest to newest, DTLS versions rank the same as the TLS
+// version they are based on.
+var tlsVersionRanks = map[TLSVersion]int{
+	SSLv2:   1,
+	SSLv3:   2,
+	TLSv10:  3,
+	TLSv11:  4,
+	DTLSv10: 4,
+	TLSv12:  5,
+	DTLSv12: 5,
+	TLSv13:  6,
+	DTLSv13: 6,
+}
+
+// ParseTLSVersion returns the version for the given name as logged by zeek.  TLS 1.3 drafts
+// (ie: TLSv13-draft23) are treated as TLS 1.3 and unknown-N gives the raw wire value.
+func ParseTLSVersion(givenName string) (TLSVersion, bool) {
+	for version, name := range tlsVersionNames {
+		if name == givenName {
+			return version, true
+		}
+	}
+	if strings.HasPrefix(givenName, "TLSv13-draft") {
+		return TLSv13, true
+	}
+	if strings.HasPrefix(givenName, "unknown-") {
+		raw, err := strconv.ParseUint(strings.TrimPrefix(givenName, "unknown-"), 10, 16)
+		if err == nil {
+			return TLSVersion(raw), true
+		}
+	}
+	return TLSVersionUnknown, false
+}
+
+// String returns the name zeek logs for the version (ie: TLSv12) or unknown-N.
+func (v TLSVersion) String() string {
+	if name, ok := tlsVersionNames[v]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown-%d", uint16(v))
+}
+
+// Rank returns the position of the version from oldest (1) to newest, or 0 if unknown.
+func (v TLSVersion) Rank() int {
+	return tlsVersionRanks[v]
+}
+
+// IsKnown tells if the version is one of the named versions.
+func (v TLSVersion) IsKnown() bool {
+	return v.Rank() > 0
+}
+
+// IsDTLS tells if the version is a DTLS version.
+func (v TLSVersion) IsDTLS() bool {
+	return v == DTLSv10 || v == DTLSv12 || v == DTLSv13
+}
+
+// Compare returns -1, 0 or 1 if this version is older, the same or newer than the given one.
+// DTLS versions compare equal to the TLS version they are based on and unknown versions are
+// older than any known version.
+func (v TLSVersion) Compare(givenVersion TLSVersion) int {
+	switch {
+	case v.Rank() < givenVersion.Rank():
+		return -1
+	case v.Rank() > givenVersion.Rank():
+		return 1
+	}
+	return 0
+}
+
+// OlderThan tells if this version is older than the given one.
+func (v TLSVersion) OlderThan(givenVersion TLSVersion) bool {
+	return v.Compare(givenVersion) < 0
+}
+
+// IsDeprecated tells if the version is older than TLS 1.2 (see RFC 8996).
+func (v TLSVersion) IsDeprecated() bool {
+	return v.IsKnown() && v.OlderThan(TLSv12)
+}
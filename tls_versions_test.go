@@ -0,0 +1,36 @@
+package zeekparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseTLSVersion(t *testing.T) {
+	for _, thisCase := range []struct {
+		name    string
+		version TLSVersion
+	}{{"SSLv3", SSLv3}, {"TLSv10", TLSv10}, {"TLSv12", TLSv12}, {"TLSv13-draft23", TLSv13}, {"DTLSv12", DTLSv12}, {"unknown-64250", 64250}} {
+		version, ok := ParseTLSVersion(thisCase.name)
+		assert.True(t, ok, thisCase.name)
+		assert.Equal(t, thisCase.version, version, thisCase.name)
+	}
+	_, ok := ParseTLSVersion("")
+	assert.False(t, ok)
+	assert.Equal(t, "TLSv11", TLSv11.String())
+	assert.Equal(t, "unknown-64250", TLSVersion(64250).String())
+}
+
+func TestTLSVersionOrdering(t *testing.T) {
+	assert.True(t, TLSv10.OlderThan(TLSv12))
+	assert.True(t, SSLv2.OlderThan(SSLv3))
+	assert.False(t, TLSv13.OlderThan(TLSv12))
+	assert.Equal(t, 0, DTLSv12.Compare(TLSv12))
+	assert.Equal(t, 1, DTLSv13.Compare(TLSv12))
+	assert.True(t, TLSVersion(64250).OlderThan(SSLv2))
+
+	assert.True(t, TLSv11.IsDeprecated())
+	assert.True(t, DTLSv10.IsDeprecated())
+	assert.False(t, TLSv12.IsDeprecated())
+	assert.False(t, TLSVersionUnknown.IsDeprecated())
+	assert.True(t, DTLSv13.IsDTLS())
+}
@@ -0,0 +1,128 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"time"
+)
+
+// tunnel log format described in https://docs.zeek.org/en/master/scripts/base/frameworks/tunnels/main.zeek.html#type-Tunnel::Info
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// TunnelEntry is a fully parsed tunnel.log line.
+type TunnelEntry struct {
+	TS      time.Time // TS:time - timestamp
+	Uid     string    // Uid:string - unique id
+	IdOrigH string    // id_orig_h:addr - senders address
+	IdOrigP int       // id_orig_p:addr - senders port
+	IdRespH string    // id_resp_h:port - responders address
+	IdRespP int       // id_resp_p:port - responders port
+	// ---------
+	TunnelType string // tunnel_type:enum - the type of tunnel (ie: Tunnel::TEREDO)
+	Action     string // action:enum - the type of activity that occurred (ie: Tunnel::DISCOVER)
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (t *TunnelEntry) Print() {
+	fmt.Printf("(%s) client {%s:%d} talks to {%s:%d}:\n",
+		t.TS.String(), t.IdOrigH, t.IdOrigP, t.IdRespH, t.IdRespP)
+	fmt.Printf("\t%s %s\n", t.TunnelType, t.Action)
+}
+
+func (t *TunnelEntry) ShortPrint() {
+	fmt.Printf("[%s] %s -> %s %s %s\n", t.TS, t.IdOrigH, t.IdRespH, t.TunnelType, t.Action)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToTunnelStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (tunnelEntry TunnelEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			tunnelEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "uid":
+			tunnelEntry.Uid = thisField.value
+		case "id.orig_h":
+			tunnelEntry.IdOrigH = thisField.value
+		case "id.orig_p":
+			tunnelEntry.IdOrigP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "id.resp_h":
+			tunnelEntry.IdRespH = thisField.value
+		case "id.resp_p":
+			tunnelEntry.IdRespP, err = strconv.Atoi(thisField.value)
+			if err != nil {
+				return
+			}
+		case "tunnel_type":
+			tunnelEntry.TunnelType = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "action":
+			tunnelEntry.Action = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseTunnelLog will parse through the given single tunnel log (passed as a filename string)
+func ParseTunnelLog(givenFilename string) (parsedResults []TunnelEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var thisRes TunnelEntry
+		thisRes, err = thisLogEntryToTunnelStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, thisRes)
+	}
+	return
+}
+
+// ParseTunnelRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseTunnelRecurse(givenDirectory string) (allResults []TunnelEntry, err error) {
+	for thisFile := range PathRecurse(givenDirectory, "tunnel") {
+		thisResult, parseErr := ParseTunnelLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllTunnelForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed TunnelEntry objects
+func GetAllTunnelForDay(givenDay string, givenZeekDir ...string) (allRes []TunnelEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseTunnelRecurse(zeekDir + givenDay + "/")
+	return
+}
@@ -0,0 +1,27 @@
+package zeekparse
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThisLogEntryToTunnelStruct(t *testing.T) {
+	log.SetFormatter(&log.TextFormatter{ForceColors: true})
+	log.SetLevel(log.InfoLevel)
+
+	// compressed case
+	compressedResults, header, compErr := parseZeekLog("test_input/simple_tunnel.log.gz")
+	for _, thisResult := range compressedResults {
+		_, parseErr := thisLogEntryToTunnelStruct(thisResult, header)
+		assert.NoError(t, parseErr)
+	}
+	assert.NoError(t, compErr)
+
+	allTunnel, err := ParseTunnelLog("test_input/simple_tunnel.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(allTunnel))
+	assert.Equal(t, "Tunnel::TEREDO", allTunnel[0].TunnelType)
+	assert.Equal(t, "Tunnel::CLOSE", allTunnel[1].Action)
+	assert.Equal(t, "10.2.2.2", allTunnel[1].IdRespH)
+}
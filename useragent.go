@@ -0,0 +1,253 @@
+/*
+User-agent classification for http.log.  This isn't a full user-agent database, it picks out
+the common browsers, operating systems, command line tools and http libraries so scripted
+traffic stands out from people browsing.
+*/
+
+package zeekparse
+
+import (
+	"sort"
+	"strings"
+)
+
+// ------------------------------
+// ----- Helper Structures ------
+// ------------------------------
+
+// UserAgentClass is the kind of client a user agent belongs to.
+type UserAgentClass string
+
+const (
+	UserAgentBrowser UserAgentClass = "browser" // a web browser
+	UserAgentTool    UserAgentClass = "tool"    // a command line tool (ie: curl, wget)
+	UserAgentLibrary UserAgentClass = "library" // an http library used by a script or program (ie: python-requests)
+	UserAgentBot     UserAgentClass = "bot"     // a crawler or other bot
+	UserAgentSystem  UserAgentClass = "system"  // an operating system service (ie: windows update, apt)
+	UserAgentEmpty   UserAgentClass = "empty"   // no user agent was sent
+	UserAgentUnknown UserAgentClass = "unknown" // anything not recognised
+)
+
+// UserAgent is a classified user agent string.
+type UserAgent struct {
+	Raw     string
+	Class   UserAgentClass
+	Name    string // ie: Firefox, curl, python-requests
+	Version string // version of Name if one was given
+	OS      string // ie: Windows, macOS, Android, blank if not given
+}
+
+// userAgentRule recognises a client by a product token in the user agent.
+type userAgentRule struct {
+	token string // product token matched ignoring case, the version follows a / after it
+	name  string
+	class UserAgentClass
+}
+
+// userAgentRules are checked in order so products that include others' tokens (ie: Edge and
+// Opera also claim Chrome and Safari) must come first.
+var userAgentRules = []userAgentRule{
+	// bots
+	{"Googlebot", "Googlebot", UserAgentBot},
+	{"bingbot", "bingbot", UserAgentBot},
+	{"YandexBot", "YandexBot", UserAgentBot},
+	{"DuckDuckBot", "DuckDuckBot", UserAgentBot},
+	{"Baiduspider", "Baiduspider", UserAgentBot},
+	// command line tools
+	{"curl", "curl", UserAgentTool},
+	{"Wget", "Wget", UserAgentTool},
+	{"HTTPie", "HTTPie", UserAgentTool},
+	{"WindowsPowerShell", "PowerShell", UserAgentTool},
+	{"PowerShell", "PowerShell", UserAgentTool},
+	{"Nmap Scripting Engine", "Nmap", UserAgentTool},
+	{"sqlmap", "sqlmap", UserAgentTool},
+	{"masscan", "masscan", UserAgentTool},
+	{"zgrab", "zgrab", UserAgentTool},
+	{"HeadlessChrome", "HeadlessChrome", UserAgentTool},
+	// libraries
+	{"python-requests", "python-requests", UserAgentLibrary},
+	{"Python-urllib", "Python-urllib", UserAgentLibrary},
+	{"python-httpx", "python-httpx", UserAgentLibrary},
+	{"aiohttp", "aiohttp", UserAgentLibrary},
+	{"Go-http-client", "Go-http-client", UserAgentLibrary},
+	{"okhttp", "okhttp", UserAgentLibrary},
+	{"Apache-HttpClient", "Apache-HttpClient", UserAgentLibrary},
+	{"Java", "Java", UserAgentLibrary},
+	{"axios", "axios", UserAgentLibrary},
+	{"node-fetch", "node-fetch", UserAgentLibrary},
+	{"libwww-perl", "libwww-perl", UserAgentLibrary},
+	{"Ruby", "Ruby", UserAgentLibrary},
+	{"WinHttp", "WinHttp", UserAgentLibrary},
+	// operating system services
+	{"Microsoft-CryptoAPI", "Microsoft-CryptoAPI", UserAgentSystem},
+	{"Windows-Update-Agent", "Windows-Update-Agent", UserAgentSystem},
+	{"Microsoft-Delivery-Optimization", "Microsoft-Delivery-Optimization", UserAgentSystem},
+	{"Microsoft NCSI", "Microsoft NCSI", UserAgentSystem},
+	{"Debian APT-HTTP", "APT", UserAgentSystem},
+	{"APT-HTTP", "APT", UserAgentSystem},
+	{"apt-cacher", "apt-cacher", UserAgentSystem},
+	{"ubuntu-release-upgrader", "ubuntu-release-upgrader", UserAgentSystem},
+	{"CaptiveNetworkSupport", "CaptiveNetworkSupport", UserAgentSystem},
+	// browsers
+	{"Edg", "Edge", UserAgentBrowser},
+	{"Edge", "Edge", UserAgentBrowser},
+	{"OPR", "Opera", UserAgentBrowser},
+	{"Opera", "Opera", UserAgentBrowser},
+	{"SamsungBrowser", "Samsung Internet", UserAgentBrowser},
+	{"Firefox", "Firefox", UserAgentBrowser},
+	{"CriOS", "Chrome", UserAgentBrowser},
+	{"Chrome", "Chrome", UserAgentBrowser},
+	{"MSIE", "Internet Explorer", UserAgentBrowser},
+	{"Trident", "Internet Explorer", UserAgentBrowser},
+}
+
+// userAgentOSes are checked in order against the user agent, the first match is the OS.
+var userAgentOSes = []struct {
+	token string
+	name  string
+}{
+	{"Windows Phone", "Windows Phone"},
+	{"Windows", "Windows"},
+	{"iPhone", "iOS"},
+	{"iPad", "iOS"},
+	{"CPU OS", "iOS"},
+	{"Mac OS X", "macOS"},
+	{"Macintosh", "macOS"},
+	{"CrOS", "ChromeOS"},
+	{"Android", "Android"},
+	{"Linux", "Linux"},
+	{"FreeBSD", "FreeBSD"},
+}
+
+// userAgentBotHints are words that mark a user agent as a bot if no rule matched.
+var userAgentBotHints = []string{"bot", "crawler", "spider", "slurp"}
+
+// productVersion returns the version after the given product token (ie: 7.68.0 for curl in
+// curl/7.68.0), ok is false if the token isn't in the user agent as a product.
+func productVersion(givenUserAgent string, givenToken string) (version string, ok bool) {
+	lowerUserAgent := asciiLower(givenUserAgent)
+	lowerToken := asciiLower(givenToken)
+	for start := 0; start < len(lowerUserAgent); {
+		idx := strings.Index(lowerUserAgent[start:], lowerToken)
+		if idx < 0 {
+			return
+		}
+		idx += start
+		end := idx + len(lowerToken)
+		start = end
+		// the token must be a whole word
+		if idx > 0 && isUserAgentWordChar(lowerUserAgent[idx-1]) {
+			continue
+		}
+		if end < len(lowerUserAgent) && isUserAgentWordChar(lowerUserAgent[end]) {
+			continue
+		}
+		ok = true
+		if end < len(givenUserAgent) && (givenUserAgent[end] == '/' || givenUserAgent[end] == ' ') {
+			rest := strings.TrimLeft(givenUserAgent[end+1:], " ")
+			if givenUserAgent[end] == ' ' && (len(rest) == 0 || rest[0] < '0' || rest[0] > '9') {
+				return
+			}
+			if stop := strings.IndexAny(rest, " ;)("); stop >= 0 {
+				rest = rest[:stop]
+			}
+			version = rest
+		}
+		return
+	}
+	return
+}
+
+// asciiLower lower cases only ASCII letters so byte offsets into the result match the original,
+// user agents are not always valid UTF-8.
+func asciiLower(givenStr string) string {
+	lowered := []byte(givenStr)
+	for idx, thisChar := range lowered {
+		if thisChar >= 'A' && thisChar <= 'Z' {
+			lowered[idx] = thisChar + ('a' - 'A')
+		}
+	}
+	return string(lowered)
+}
+
+// isUserAgentWordChar tells if the given char can be part of a product token.
+func isUserAgentWordChar(givenChar byte) bool {
+	return givenChar == '-' || givenChar == '_' ||
+		(givenChar >= 'a' && givenChar <= 'z') || (givenChar >= 'A' && givenChar <= 'Z')
+}
+
+// ParseUserAgent classifies the given user agent string.
+func ParseUserAgent(givenUserAgent string) (ua UserAgent) {
+	ua.Raw = givenUserAgent
+	trimmed := strings.TrimSpace(givenUserAgent)
+	if len(trimmed) == 0 || trimmed == "-" {
+		ua.Class = UserAgentEmpty
+		return
+	}
+
+	for _, thisOS := range userAgentOSes {
+		if strings.Contains(givenUserAgent, thisOS.token) {
+			ua.OS = thisOS.name
+			break
+		}
+	}
+
+	ua.Class = UserAgentUnknown
+	for _, thisRule := range userAgentRules {
+		if version, ok := productVersion(givenUserAgent, thisRule.token); ok {
+			ua.Name, ua.Version, ua.Class = thisRule.name, version, thisRule.class
+			return
+		}
+	}
+
+	// Safari only identifies itself as Safari with its version in a separate Version token
+	if _, ok := productVersion(givenUserAgent, "Safari"); ok && strings.HasPrefix(givenUserAgent, "Mozilla/") {
+		ua.Name, ua.Class = "Safari", UserAgentBrowser
+		ua.Version, _ = productVersion(givenUserAgent, "Version")
+		return
+	}
+
+	lowerUserAgent := strings.ToLower(givenUserAgent)
+	for _, thisHint := range userAgentBotHints {
+		if strings.Contains(lowerUserAgent, thisHint) {
+			ua.Class = UserAgentBot
+			return
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ----    Entry Helpers   ------
+// ------------------------------
+
+// IsEmpty tells if no user agent was sent.
+func (u UserAgent) IsEmpty() bool {
+	return u.Class == UserAgentEmpty
+}
+
+// IsScripted tells if the user agent is a command line tool or http library rather than a
+// browser or system service.
+func (u UserAgent) IsScripted() bool {
+	return u.Class == UserAgentTool || u.Class == UserAgentLibrary
+}
+
+// RareUserAgents returns the user agents sent by at most the given number of distinct clients,
+// along with the sorted clients that sent them.  Requests without a user agent are skipped.
+func RareUserAgents(givenHttp []HttpEntry, givenMaxClients int) map[string][]string {
+	clientsByAgent := make(map[string][]string)
+	for _, thisHttp := range givenHttp {
+		if len(strings.TrimSpace(thisHttp.UserAgent)) == 0 {
+			continue
+		}
+		clientsByAgent[thisHttp.UserAgent] = appendUnique(clientsByAgent[thisHttp.UserAgent], thisHttp.IdOrigH)
+	}
+	for thisAgent, thisClients := range clientsByAgent {
+		if len(thisClients) > givenMaxClients {
+			delete(clientsByAgent, thisAgent)
+			continue
+		}
+		sort.Strings(thisClients)
+	}
+	return clientsByAgent
+}
@@ -0,0 +1,68 @@
+package zeekparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseUserAgent(t *testing.T) {
+	for _, thisCase := range []struct {
+		raw     string
+		class   UserAgentClass
+		name    string
+		version string
+		os      string
+	}{
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:109.0) Gecko/20100101 Firefox/118.0", UserAgentBrowser, "Firefox", "118.0", "Windows"},
+		{"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/88.0.4324.182 Safari/537.36", UserAgentBrowser, "Chrome", "88.0.4324.182", "Linux"},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/118.0.0.0 Safari/537.36 Edg/118.0.2088.46", UserAgentBrowser, "Edge", "118.0.2088.46", "Windows"},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1", UserAgentBrowser, "Safari", "17.0", "iOS"},
+		{"Mozilla/5.0 (Linux; Android 13; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/118.0.0.0 Mobile Safari/537.36", UserAgentBrowser, "Chrome", "118.0.0.0", "Android"},
+		{"Mozilla/4.0 (compatible; MSIE 8.0; Windows NT 6.1; Trident/4.0)", UserAgentBrowser, "Internet Explorer", "8.0", "Windows"},
+		{"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) HeadlessChrome/118.0.0.0 Safari/537.36", UserAgentTool, "HeadlessChrome", "118.0.0.0", "Linux"},
+		{"curl/7.68.0", UserAgentTool, "curl", "7.68.0", ""},
+		{"Wget/1.21.2", UserAgentTool, "Wget", "1.21.2", ""},
+		{"Mozilla/5.0 (Windows NT; Windows NT 10.0; en-US) WindowsPowerShell/5.1.19041.3570", UserAgentTool, "PowerShell", "5.1.19041.3570", "Windows"},
+		{"python-requests/2.31.0", UserAgentLibrary, "python-requests", "2.31.0", ""},
+		{"Python-urllib/3.10", UserAgentLibrary, "Python-urllib", "3.10", ""},
+		{"Go-http-client/1.1", UserAgentLibrary, "Go-http-client", "1.1", ""},
+		{"Java/1.8.0_151", UserAgentLibrary, "Java", "1.8.0_151", ""},
+		{"Microsoft-CryptoAPI/10.0", UserAgentSystem, "Microsoft-CryptoAPI", "10.0", ""},
+		{"Debian APT-HTTP/1.3 (2.6.1)", UserAgentSystem, "APT", "1.3", ""},
+		{"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)", UserAgentBot, "Googlebot", "2.1", ""},
+		{"SomeCrawler/0.1", UserAgentBot, "", "", ""},
+		{"MyInternalApp", UserAgentUnknown, "", "", ""},
+		{"", UserAgentEmpty, "", "", ""},
+		{"-", UserAgentEmpty, "", "", ""},
+	} {
+		ua := ParseUserAgent(thisCase.raw)
+		assert.Equal(t, thisCase.raw, ua.Raw)
+		assert.Equal(t, thisCase.class, ua.Class, thisCase.raw)
+		assert.Equal(t, thisCase.name, ua.Name, thisCase.raw)
+		assert.Equal(t, thisCase.version, ua.Version, thisCase.raw)
+		assert.Equal(t, thisCase.os, ua.OS, thisCase.raw)
+	}
+
+	// product tokens must be whole words
+	assert.Equal(t, UserAgentUnknown, ParseUserAgent("libcurl-agent").Class)
+	assert.Equal(t, UserAgentUnknown, ParseUserAgent("JavaScriptCore").Class)
+
+	assert.True(t, ParseUserAgent("curl/8.4.0").IsScripted())
+	assert.False(t, ParseUserAgent("Microsoft-CryptoAPI/10.0").IsScripted())
+	assert.True(t, ParseUserAgent(" ").IsEmpty())
+}
+
+func TestRareUserAgents(t *testing.T) {
+	allHttp, err := ParseHttpLog("test_input/simple_http_urls.log")
+	assert.NoError(t, err)
+	assert.True(t, allHttp[3].ParsedUserAgent().IsEmpty())
+	assert.Equal(t, "python-requests", allHttp[1].ParsedUserAgent().Name)
+
+	// the firefox agent is seen from two clients
+	rare := RareUserAgents(allHttp, 1)
+	assert.Equal(t, 4, len(rare))
+	assert.Equal(t, []string{"192.168.1.111"}, rare["curl/8.4.0"])
+	assert.NotContains(t, rare, "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:109.0) Gecko/20100101 Firefox/118.0")
+
+	assert.Equal(t, 5, len(RareUserAgents(allHttp, 2)))
+}
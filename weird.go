@@ -0,0 +1,192 @@
+package zeekparse
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+// weird log format described in https://docs.zeek.org/en/master/scripts/base/frameworks/notice/weird.zeek.html#type-Weird::Info
+
+// ------------------------------
+// ------ Entry Structure -------
+// ------------------------------
+
+// WeirdEntry is a fully parsed weird.log line.  Weirds that are not tied to a connection
+// have a blank Uid and addresses and unset ports.
+type WeirdEntry struct {
+	TS      time.Time // TS:time - timestamp
+	Uid     string    // Uid:string - unique id
+	IdOrigH string    // id_orig_h:addr - senders address
+	IdOrigP Opt[int]  // id_orig_p:addr - senders port
+	IdRespH string    // id_resp_h:port - responders address
+	IdRespP Opt[int]  // id_resp_p:port - responders port
+	// ---------
+	Name   string // name:string - name of the weird that occurred
+	Addl   string // addl:string - additional information accompanying the weird if any
+	Notice bool   // notice:bool - indicate if this weird was also turned into a notice
+	Peer   string // peer:string - peer that originated this weird
+	Source string // source:string - source of the weird, usually the name of the analyzer that raised it
+}
+
+// ------------------------------
+// ----    Entry Prints   -------
+// ------------------------------
+
+func (w *WeirdEntry) Print() {
+	fmt.Printf("(%s) weird {%s:%s} -> {%s:%s}:\n",
+		w.TS.String(), w.IdOrigH, w.IdOrigP, w.IdRespH, w.IdRespP)
+	fmt.Printf("\t%s %s\n", w.Name, w.Addl)
+}
+
+func (w *WeirdEntry) ShortPrint() {
+	fmt.Printf("[%s] %s %s\n", w.TS, w.IdOrigH, w.Name)
+}
+
+// ------------------------------
+// ---- Main Parse Function -----
+// ------------------------------
+
+func thisLogEntryToWeirdStruct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogFileOpts) (weirdEntry WeirdEntry, err error) {
+	if len(givenLogOpts.setSeparator) == 0 {
+		err = errors.New("no set seperator in header can't parse")
+		return
+	}
+
+	for _, thisField := range givenZeekLogEntry {
+		switch thisField.fieldName {
+		case "ts":
+			weirdEntry.TS, err = UnixStrToTime(thisField.value)
+			if err != nil {
+				return
+			}
+		case "uid":
+			weirdEntry.Uid = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "id.orig_h":
+			weirdEntry.IdOrigH = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "id.orig_p":
+			weirdEntry.IdOrigP, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "id.resp_h":
+			weirdEntry.IdRespH = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "id.resp_p":
+			weirdEntry.IdRespP, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "name":
+			weirdEntry.Name = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "addl":
+			weirdEntry.Addl = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "notice":
+			weirdEntry.Notice = thisField.value == "T"
+		case "peer":
+			weirdEntry.Peer = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "source":
+			weirdEntry.Source = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		}
+	}
+	return
+}
+
+// ------------------------------
+// ---- File Parse Recurse  -----
+// ------------------------------
+
+// ParseWeirdLog will parse through the given single weird log (passed as a filename string)
+func ParseWeirdLog(givenFilename string) (parsedResults []WeirdEntry, err error) {
+	allUnparsedEntries, header, initialParseErr := parseZeekLog(givenFilename)
+	if initialParseErr != nil {
+		err = initialParseErr
+		return
+	}
+	for _, thisResult := range allUnparsedEntries {
+		var weirdRes WeirdEntry
+		weirdRes, err = thisLogEntryToWeirdStruct(thisResult, header)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		parsedResults = append(parsedResults, weirdRes)
+	}
+	return
+}
+
+// ParseWeirdRecurse will parse through the given directory and recurse further down (passed as a directory string)
+func ParseWeirdRecurse(givenDirectory string) (allResults []WeirdEntry, err error) {
+	for thisFile := range PathRecursePrefix(givenDirectory, "weird.") {
+		thisResult, parseErr := ParseWeirdLog(thisFile)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		allResults = append(allResults, thisResult...)
+	}
+	return
+}
+
+// GetAllWeirdForDay returns all entries on the given day from the default zeek directory as a slice of
+// parsed WeirdEntry objects
+func GetAllWeirdForDay(givenDay string, givenZeekDir ...string) (allRes []WeirdEntry, err error) {
+	zeekDir := GetZeekDir(givenZeekDir)
+	allRes, err = ParseWeirdRecurse(zeekDir + givenDay + "/")
+	return
+}
+
+// ------------------------------
+// ------  Weird Rollups  -------
+// ------------------------------
+
+// WeirdRollup counts weird names per originating host and per day.  Weirds are also
+// kept by uid so they can be joined back to the ConnEntry they were raised on.
+type WeirdRollup struct {
+	ByHost map[string]map[string]int // originating host -> weird name -> count
+	ByDay  map[string]map[string]int // day (YYYY-MM-DD) -> weird name -> count
+	ByUid  map[string][]WeirdEntry   // connection uid -> weirds raised on it
+}
+
+// NewWeirdRollup builds a WeirdRollup from the given weird entries.  Weirds without a
+// host or uid are still counted per day.
+func NewWeirdRollup(givenEntries []WeirdEntry) *WeirdRollup {
+	r := new(WeirdRollup)
+	r.ByHost = make(map[string]map[string]int)
+	r.ByDay = make(map[string]map[string]int)
+	r.ByUid = make(map[string][]WeirdEntry)
+
+	for _, thisWeird := range givenEntries {
+		if len(thisWeird.IdOrigH) > 0 {
+			if r.ByHost[thisWeird.IdOrigH] == nil {
+				r.ByHost[thisWeird.IdOrigH] = make(map[string]int)
+			}
+			r.ByHost[thisWeird.IdOrigH][thisWeird.Name]++
+		}
+
+		thisDay := TimeToDateStr(thisWeird.TS)
+		if r.ByDay[thisDay] == nil {
+			r.ByDay[thisDay] = make(map[string]int)
+		}
+		r.ByDay[thisDay][thisWeird.Name]++
+
+		if len(thisWeird.Uid) > 0 {
+			r.ByUid[thisWeird.Uid] = append(r.ByUid[thisWeird.Uid], thisWeird)
+		}
+	}
+	return r
+}
+
+// ForConn returns the weirds that were raised on the given connection.
+func (r *WeirdRollup) ForConn(givenConn ConnEntry) []WeirdEntry {
+	return r.ByUid[givenConn.Uid]
+}
+
+// Print will print the per host weird counts to the screen.
+func (r *WeirdRollup) Print() {
+	for thisHost, thisCounts := range r.ByHost {
+		for thisName, thisCount := range thisCounts {
+			fmt.Printf("%s %s: %d\n", thisHost, thisName, thisCount)
+		}
+	}
+}
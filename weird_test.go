@@ -0,0 +1,38 @@
+package zeekparse
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThisLogEntryToWeirdStruct(t *testing.T) {
+	log.SetFormatter(&log.TextFormatter{ForceColors: true})
+	log.SetLevel(log.InfoLevel)
+
+	// compressed case
+	compressedResults, header, compErr := parseZeekLog("test_input/simple_weird.log.gz")
+	for _, thisResult := range compressedResults {
+		_, weirdErr := thisLogEntryToWeirdStruct(thisResult, header)
+		assert.NoError(t, weirdErr)
+	}
+	assert.NoError(t, compErr)
+}
+
+func TestNewWeirdRollup(t *testing.T) {
+	allWeirds, err := ParseWeirdLog("test_input/simple_weird.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, len(allWeirds))
+
+	// the last weird is not tied to a connection
+	assert.Equal(t, "", allWeirds[4].Uid)
+	assert.False(t, allWeirds[4].IdOrigP.IsSet())
+
+	rollup := NewWeirdRollup(allWeirds)
+	assert.Equal(t, 2, rollup.ByHost["192.168.1.110"]["bad_TCP_checksum"])
+	assert.Equal(t, 1, rollup.ByHost["192.168.1.139"]["dns_unmatched_reply"])
+	assert.Equal(t, 1, rollup.ByDay["2021-05-16"]["truncated_IP"])
+
+	conn := ConnEntry{Uid: "CA1ojA4xixNTLa5XQf"}
+	assert.Equal(t, 2, len(rollup.ForConn(conn)))
+}
@@ -17,7 +17,8 @@ import (
 
 type X509Entry struct {
 	TS      time.Time 				// TS:time - timestamp
-	Id     string    				// id:string - unique id
+	Id     string    				// id:string - unique id, blank on newer zeek versions which log fingerprint instead
+	Fingerprint string				// fingerprint:string - sha256 fingerprint of the certificate, newer zeek versions only
 	// ---------
 	CertVersion int					// certificate.version:count - x509 version number
 	CertSerial string				// certificate.serial:string - x509 serial
@@ -28,7 +29,20 @@ type X509Entry struct {
 	CertKeyAlg string				// certificate.key_alg:string - name of key algorithm
 	CertSigAlg string				// certificate.sig_alg:string - name of sig algorithm
 	CertKeyType string				// certificate.key_type:string - key type (rsa, dsa, etc)
-	CertKeyLength int				// certificate.key_length:count - key length (bits)
+	CertKeyLength Opt[int]				// certificate.key_length:count - key length (bits)
+	CertExponent string				// certificate.exponent:string - exponent, if RSA
+	CertCurve string				// certificate.curve:string - curve, if EC
+	// ---------
+	SanDns []string					// san.dns:vector[string] - list of DNS entries in the subject alternative name
+	SanUri []string					// san.uri:vector[string] - list of URI entries in the subject alternative name
+	SanEmail []string				// san.email:vector[string] - list of email entries in the subject alternative name
+	SanIp []string					// san.ip:vector[addr] - list of IP entries in the subject alternative name
+	// ---------
+	BasicConstraintsCA Opt[bool]	// basic_constraints.ca:bool - CA flag set, unset if there was no basic constraints extension
+	BasicConstraintsPathLen Opt[int]		// basic_constraints.path_len:count - maximum path length
+	// ---------
+	HostCert bool					// host_cert:bool - indicates that this certificate was sent by the server
+	ClientCert bool					// client_cert:bool - indicates that this certificate was sent by the client
 }
 
 // ------------------------------
@@ -36,7 +50,7 @@ type X509Entry struct {
 // ------------------------------
 
 func (s *X509Entry) Print() {
-	fmt.Printf("(%s) %d bit %s cert: %s  validity:%s--%s issuer:%s\n",
+	fmt.Printf("(%s) %s bit %s cert: %s  validity:%s--%s issuer:%s\n",
 		s.TS.String(), s.CertKeyLength, s.CertKeyType ,s.CertSubject,
 		s.CertNotValidBefore.Format("01/02/06"), s.CertNotValidAfter.Format("01/02/06"),
 		s.CertIssuer)
@@ -47,6 +61,39 @@ func (s *X509Entry) ShortPrint() {
 		s.TS, s.CertSubject)
 }
 
+// ------------------------------
+// ----    Entry Helpers   ------
+// ------------------------------
+
+// CertId returns the id of the certificate for joining to other logs, the file id on older
+// zeek versions and the fingerprint on newer ones.
+func (s *X509Entry) CertId() string {
+	if len(s.Id) > 0 {
+		return s.Id
+	}
+	return s.Fingerprint
+}
+
+// SubjectDN returns the parsed subject of the certificate.
+func (s *X509Entry) SubjectDN() (DistinguishedName, error) {
+	return ParseDN(s.CertSubject)
+}
+
+// IssuerDN returns the parsed issuer of the certificate.
+func (s *X509Entry) IssuerDN() (DistinguishedName, error) {
+	return ParseDN(s.CertIssuer)
+}
+
+// CommonName returns the CN of the certificate subject, blank if it has none.
+func (s *X509Entry) CommonName() string {
+	return commonNameOf(s.CertSubject)
+}
+
+// IsSelfSigned tells if the certificate subject and issuer are the same name.
+func (s *X509Entry) IsSelfSigned() bool {
+	return len(s.CertSubject) > 0 && sameDN(s.CertSubject, s.CertIssuer)
+}
+
 // ------------------------------
 // ---- Main Parse Function -----
 // ------------------------------
@@ -65,7 +112,9 @@ func thisLogEntryToX509Struct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogF
 				return
 			}
 		case "id":
-			X509Entry.Id = thisField.value
+			X509Entry.Id = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "fingerprint":
+			X509Entry.Fingerprint = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
 		case "certificate.version":
 			X509Entry.CertVersion, err = strconv.Atoi(thisField.value)
 			if err != nil {
@@ -94,10 +143,33 @@ func thisLogEntryToX509Struct(givenZeekLogEntry ZeekLogEntry, givenLogOpts *LogF
 		case "certificate.key_type":
 			X509Entry.CertKeyType = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
 		case "certificate.key_length":
-			X509Entry.CertKeyLength, err = strconv.Atoi(thisField.value)
+			X509Entry.CertKeyLength, err = optInt(thisField.value, givenLogOpts.unsetField)
+			if err != nil {
+				return
+			}
+		case "certificate.exponent":
+			X509Entry.CertExponent = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "certificate.curve":
+			X509Entry.CertCurve = StrBlankIfUnset(thisField.value, givenLogOpts.unsetField)
+		case "san.dns":
+			X509Entry.SanDns = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "san.uri":
+			X509Entry.SanUri = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "san.email":
+			X509Entry.SanEmail = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "san.ip":
+			X509Entry.SanIp = strSliceNilIfUnset(thisField.value, givenLogOpts)
+		case "basic_constraints.ca":
+			X509Entry.BasicConstraintsCA = optBool(thisField.value)
+		case "basic_constraints.path_len":
+			X509Entry.BasicConstraintsPathLen, err = optInt(thisField.value, givenLogOpts.unsetField)
 			if err != nil {
 				return
 			}
+		case "host_cert":
+			X509Entry.HostCert = thisField.value == "T"
+		case "client_cert":
+			X509Entry.ClientCert = thisField.value == "T"
 		}
 	}
 	return
@@ -19,3 +19,43 @@ func TestThisLogEntryToX509Struct(t *testing.T) {
 	assert.NoError(t, compErr)
 }
 
+func TestParseX509LogFullSchema(t *testing.T) {
+	// older zeek with id and no fingerprint
+	allX509, err := ParseX509Log("test_input/simple_x509.log")
+	assert.NoError(t, err)
+	assert.Equal(t, "FgR2UlAImauxnHCO9", allX509[0].CertId())
+	assert.Equal(t, "", allX509[0].Fingerprint)
+	assert.Equal(t, []string{"download.jetbrains.com"}, allX509[0].SanDns)
+	assert.Equal(t, Some(false), allX509[0].BasicConstraintsCA)
+	assert.False(t, allX509[0].BasicConstraintsPathLen.IsSet())
+
+	// newer zeek with fingerprint in place of id
+	allX509, err = ParseX509Log("test_input/simple_x509_fingerprint.log")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(allX509))
+
+	leaf := allX509[0]
+	assert.Equal(t, "", leaf.Id)
+	assert.Equal(t, "3a1b5c7d9e2f4a6b8c0d1e3f5a7b9c2d4e6f8a0b1c3d5e7f9a2b4c6d8e0f1a3b", leaf.CertId())
+	assert.Equal(t, "65537", leaf.CertExponent)
+	assert.Equal(t, []string{"www.example.org", "example.org"}, leaf.SanDns)
+	assert.Nil(t, leaf.SanIp)
+	assert.True(t, leaf.BasicConstraintsCA.IsSet())
+	assert.False(t, leaf.BasicConstraintsCA.Value())
+	assert.True(t, leaf.HostCert)
+	assert.False(t, leaf.ClientCert)
+
+	intermediate := allX509[1]
+	assert.True(t, intermediate.BasicConstraintsCA.Value())
+	assert.Equal(t, Some(0), intermediate.BasicConstraintsPathLen)
+
+	client := allX509[2]
+	assert.Equal(t, "prime256v1", client.CertCurve)
+	assert.Equal(t, "", client.CertExponent)
+	assert.Equal(t, []string{"urn:device:laptop-17"}, client.SanUri)
+	assert.Equal(t, []string{"laptop-17@corp.example"}, client.SanEmail)
+	assert.Equal(t, []string{"10.1.2.3", "fd00::17"}, client.SanIp)
+	assert.False(t, client.BasicConstraintsCA.IsSet())
+	assert.Equal(t, "-", client.BasicConstraintsCA.String())
+	assert.True(t, client.ClientCert)
+}